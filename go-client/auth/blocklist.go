@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BlocklistSource loads the current set of sanctioned/blocked wallet
+// addresses. Implementations can pull from a URL, a local file, S3, or
+// anywhere else; URLBlocklistSource is the default used by
+// NewBlocklistChecker when no BlocklistSource is supplied.
+type BlocklistSource interface {
+	Load(ctx context.Context) ([]string, error)
+}
+
+// ErrBlocklistNotReady is returned when a BlocklistChecker is consulted
+// before Start has completed its first successful refresh. IsBlocked returns
+// false for every address until then, which is indistinguishable from "known
+// not to be blocked" unless callers check Ready (or get this error) first.
+var ErrBlocklistNotReady = fmt.Errorf("blocklist checker: Start has not completed a successful refresh yet")
+
+// DefaultBlocklistURL is the default source URLBlocklistSource fetches from.
+const DefaultBlocklistURL = "https://raw.githubusercontent.com/0xngmi/blocklists/main/sanctioned_addresses_US.json"
+
+// DefaultBlocklistRefreshInterval is how often a BlocklistChecker re-fetches
+// its source in the background after Start.
+const DefaultBlocklistRefreshInterval = 1 * time.Hour
+
+// URLBlocklistSource is a BlocklistSource that fetches a JSON array of
+// addresses from a configurable URL.
+type URLBlocklistSource struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewURLBlocklistSource creates a URLBlocklistSource for url.
+func NewURLBlocklistSource(url string) *URLBlocklistSource {
+	return &URLBlocklistSource{URL: url}
+}
+
+// Load fetches and decodes the JSON address array at s.URL.
+func (s *URLBlocklistSource) Load(ctx context.Context) ([]string, error) {
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build blocklist request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocklist: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("blocklist fetch returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blocklist response: %w", err)
+	}
+
+	var addresses []string
+	if err := json.Unmarshal(body, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to decode blocklist JSON: %w", err)
+	}
+	return addresses, nil
+}
+
+// BlocklistChecker caches a BlocklistSource's addresses in memory and
+// refreshes them on a timer, so CheckAddressBlocked can reject a blocked
+// address without doing network I/O on every signing call.
+type BlocklistChecker struct {
+	source          BlocklistSource
+	refreshInterval time.Duration
+
+	ready atomic.Bool
+
+	mu      sync.RWMutex
+	blocked map[string]struct{}
+}
+
+// NewBlocklistChecker creates a BlocklistChecker around source. It has no
+// addresses loaded and reports Ready() == false until Start completes its
+// first successful refresh. A nil source defaults to URLBlocklistSource
+// against DefaultBlocklistURL; refreshInterval <= 0 defaults to
+// DefaultBlocklistRefreshInterval.
+func NewBlocklistChecker(source BlocklistSource, refreshInterval time.Duration) *BlocklistChecker {
+	if source == nil {
+		source = NewURLBlocklistSource(DefaultBlocklistURL)
+	}
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultBlocklistRefreshInterval
+	}
+
+	return &BlocklistChecker{
+		source:          source,
+		refreshInterval: refreshInterval,
+		blocked:         toBlockedSet(nil),
+	}
+}
+
+// Start fetches the blocklist once synchronously, so the call returns only
+// once live data (or an error) is available, then refreshes it every
+// refreshInterval in the background until ctx is canceled. Ready reports
+// true once this first refresh succeeds.
+func (c *BlocklistChecker) Start(ctx context.Context) error {
+	if err := c.refresh(ctx); err != nil {
+		return err
+	}
+	c.ready.Store(true)
+
+	go func() {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = c.refresh(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Ready reports whether Start has completed at least one successful refresh.
+// IsBlocked returns false for every address before that, which looks
+// identical to "confirmed not blocked" unless a caller checks Ready (or uses
+// CheckBlocked, which returns ErrBlocklistNotReady instead) first.
+func (c *BlocklistChecker) Ready() bool {
+	return c.ready.Load()
+}
+
+// CheckBlocked is IsBlocked plus an explicit ErrBlocklistNotReady error when
+// Start hasn't completed its first successful refresh yet, for call sites
+// that would otherwise silently treat "not ready" the same as "not blocked".
+func (c *BlocklistChecker) CheckBlocked(addr string) error {
+	if !c.Ready() {
+		return ErrBlocklistNotReady
+	}
+	if c.IsBlocked(addr) {
+		return fmt.Errorf("address %s is on the sanctioned-address blocklist", addr)
+	}
+	return nil
+}
+
+func (c *BlocklistChecker) refresh(ctx context.Context) error {
+	addresses, err := c.source.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to refresh blocklist: %w", err)
+	}
+
+	c.mu.Lock()
+	c.blocked = toBlockedSet(addresses)
+	c.mu.Unlock()
+	return nil
+}
+
+// IsBlocked reports whether addr (case-insensitively) is in the cached blocklist.
+func (c *BlocklistChecker) IsBlocked(addr string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.blocked[strings.ToLower(addr)]
+	return ok
+}
+
+func toBlockedSet(addresses []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(addresses))
+	for _, addr := range addresses {
+		set[strings.ToLower(addr)] = struct{}{}
+	}
+	return set
+}
+
+// activeBlocklistChecker is the process-wide checker consulted by
+// CheckAddressBlocked. It is nil by default (the check is opt-in) and set via
+// SetBlocklistChecker, typically once at startup.
+var activeBlocklistChecker struct {
+	mu      sync.RWMutex
+	checker *BlocklistChecker
+}
+
+// SetBlocklistChecker installs checker as the process-wide blocklist
+// consulted by CheckAddressBlocked and the signing helpers that call it.
+// Pass nil to disable the check (the default), so compliance-sensitive
+// deployments can opt in with a single call at startup rather than threading
+// a checker through every signing call.
+func SetBlocklistChecker(checker *BlocklistChecker) {
+	activeBlocklistChecker.mu.Lock()
+	activeBlocklistChecker.checker = checker
+	activeBlocklistChecker.mu.Unlock()
+}
+
+// CheckAddressBlocked returns an error if addr is present in the process-wide
+// blocklist installed via SetBlocklistChecker, or if that checker was
+// installed but hasn't completed Start yet (ErrBlocklistNotReady) -- without
+// this, a checker installed-but-not-yet-Started would silently let every
+// address through, since IsBlocked reports false for everything until the
+// first successful refresh. It always returns nil until a checker has been
+// installed at all, so the check remains strictly opt-in.
+func CheckAddressBlocked(addr string) error {
+	activeBlocklistChecker.mu.RLock()
+	checker := activeBlocklistChecker.checker
+	activeBlocklistChecker.mu.RUnlock()
+
+	if checker == nil {
+		return nil
+	}
+	return checker.CheckBlocked(addr)
+}
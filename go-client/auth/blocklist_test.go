@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubSource is a fixed-list BlocklistSource for tests.
+type stubSource struct {
+	addresses []string
+	err       error
+}
+
+func (s stubSource) Load(ctx context.Context) ([]string, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.addresses, nil
+}
+
+func TestBlocklistCheckerCacheHitAndMiss(t *testing.T) {
+	checker := NewBlocklistChecker(stubSource{addresses: []string{"0xBlocked"}}, 0)
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	if !checker.IsBlocked("0xblocked") {
+		t.Fatal("expected a cache hit (case-insensitive) for a blocked address")
+	}
+	if checker.IsBlocked("0xnotblocked") {
+		t.Fatal("expected a cache miss for an address not in the list")
+	}
+}
+
+func TestBlocklistCheckerStartFailsClosedOnRefreshError(t *testing.T) {
+	checker := NewBlocklistChecker(stubSource{err: errors.New("source unreachable")}, 0)
+
+	if err := checker.Start(context.Background()); err == nil {
+		t.Fatal("expected Start to return an error when the first refresh fails")
+	}
+
+	if checker.Ready() {
+		t.Fatal("expected Ready to be false after a failed Start")
+	}
+	if err := checker.CheckBlocked("0xanything"); !errors.Is(err, ErrBlocklistNotReady) {
+		t.Fatalf("expected CheckBlocked to report ErrBlocklistNotReady, got: %v", err)
+	}
+}
+
+func TestBlocklistCheckerNotReadyBeforeStart(t *testing.T) {
+	checker := NewBlocklistChecker(stubSource{addresses: []string{"0xBlocked"}}, 0)
+
+	if checker.Ready() {
+		t.Fatal("expected Ready to be false before Start is called")
+	}
+	if checker.IsBlocked("0xanything") {
+		t.Fatal("expected IsBlocked to report false before Start, since nothing has loaded yet")
+	}
+	if err := checker.CheckBlocked("0xblocked"); !errors.Is(err, ErrBlocklistNotReady) {
+		t.Fatalf("expected CheckBlocked to report ErrBlocklistNotReady before Start, got: %v", err)
+	}
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if !checker.Ready() {
+		t.Fatal("expected Ready to be true after a successful Start")
+	}
+	if err := checker.CheckBlocked("0xblocked"); err == nil {
+		t.Fatal("expected CheckBlocked to report the now-loaded blocked address")
+	}
+}
+
+func TestCheckAddressBlockedReportsNotReady(t *testing.T) {
+	checker := NewBlocklistChecker(stubSource{addresses: []string{"0xBlocked"}}, 0)
+	SetBlocklistChecker(checker)
+	defer SetBlocklistChecker(nil)
+
+	if err := CheckAddressBlocked("0xanything"); !errors.Is(err, ErrBlocklistNotReady) {
+		t.Fatalf("expected ErrBlocklistNotReady for an installed-but-not-Started checker, got: %v", err)
+	}
+
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := CheckAddressBlocked("0xblocked"); err == nil {
+		t.Fatal("expected CheckAddressBlocked to reject the blocked address once the checker is ready")
+	}
+	if err := CheckAddressBlocked("0xnotblocked"); err != nil {
+		t.Fatalf("expected CheckAddressBlocked to allow an unblocked address, got: %v", err)
+	}
+}
+
+func TestBlocklistSourceRoundTrip(t *testing.T) {
+	var source BlocklistSource = stubSource{addresses: []string{"0xAbC", "0xDeF"}}
+
+	addresses, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	checker := NewBlocklistChecker(source, 0)
+	if err := checker.Start(context.Background()); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	for _, addr := range addresses {
+		if !checker.IsBlocked(addr) {
+			t.Fatalf("expected %s, loaded from source, to round-trip into the checker's cache", addr)
+		}
+	}
+}
+
+func TestURLBlocklistSourceLoad(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]string{"0xFeed", "0xBeef"})
+	}))
+	defer server.Close()
+
+	source := NewURLBlocklistSource(server.URL)
+	addresses, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(addresses) != 2 || addresses[0] != "0xFeed" || addresses[1] != "0xBeef" {
+		t.Fatalf("unexpected addresses: %v", addresses)
+	}
+}
@@ -2,34 +2,26 @@ package auth
 
 import (
 	"crypto/ecdsa"
-	"encoding/json"
 	"fmt"
-	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 const (
 	// MSG_TO_SIGN is the constant message to sign
 	MSG_TO_SIGN = "This is a random string to sign for CLOB authentication."
-)
 
-// EIP712Domain represents the EIP-712 domain
-type EIP712Domain struct {
-	Name              string `json:"name"`
-	Version           string `json:"version"`
-	ChainID           int64  `json:"chainId"`
-	Salt              string `json:"salt,omitempty"`
-	VerifyingContract string `json:"verifyingContract,omitempty"`
-}
+	// clobAuthDomainName is the EIP-712 domain name used for CLOB auth signatures.
+	clobAuthDomainName = "ClobAuthDomain"
 
-// EIP712Type represents EIP-712 type definition
-type EIP712Type struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
-}
+	// clobAuthDomainVersion is the EIP-712 domain version used for CLOB auth signatures.
+	clobAuthDomainVersion = "1"
+)
 
 // ClobAuthData represents CLOB authentication data
 type ClobAuthData struct {
@@ -39,190 +31,93 @@ type ClobAuthData struct {
 	Message   string `json:"message"`
 }
 
-// TypedData represents the full EIP-712 typed data structure
-type TypedData struct {
-	Types map[string][]EIP712Type `json:"types"`
-	PrimaryType string             `json:"primaryType"`
-	Domain     EIP712Domain        `json:"domain"`
-	Message    interface{}         `json:"message"`
+// BuildClobAuthTypedData builds the apitypes.TypedData for Polymarket's ClobAuth struct,
+// the same payload that the CLOB's order-signing endpoints expect for request auth.
+func BuildClobAuthTypedData(address string, chainID int64, timestamp int64, nonce uint64) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": domainTypes(clobAuthDomainName, clobAuthDomainVersion, true, false, false),
+			"ClobAuth": {
+				{Name: "address", Type: "address"},
+				{Name: "timestamp", Type: "string"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "message", Type: "string"},
+			},
+		},
+		PrimaryType: "ClobAuth",
+		Domain: apitypes.TypedDataDomain{
+			Name:    clobAuthDomainName,
+			Version: clobAuthDomainVersion,
+			ChainId: math.NewHexOrDecimal256(chainID),
+		},
+		Message: apitypes.TypedDataMessage{
+			"address":   address,
+			"timestamp": fmt.Sprintf("%d", timestamp),
+			"nonce":     fmt.Sprintf("%d", nonce),
+			"message":   MSG_TO_SIGN,
+		},
+	}
 }
 
 // BuildClobEip712Signature builds the canonical Polymarket CLOB EIP712 signature
 func BuildClobEip712Signature(privateKey *ecdsa.PrivateKey, chainID int64, timestamp int64, nonce uint64) (string, error) {
-	// Get address from private key
 	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
-
-	// Create domain
-	domain := EIP712Domain{
-		Name:    "ClobAuthDomain",
-		Version: "1",
-		ChainID: chainID,
-	}
-
-	// Create types
-	types := map[string][]EIP712Type{
-		"ClobAuth": {
-			{Name: "address", Type: "address"},
-			{Name: "timestamp", Type: "string"},
-			{Name: "nonce", Type: "uint256"},
-			{Name: "message", Type: "string"},
-		},
+	if err := CheckAddressBlocked(address); err != nil {
+		return "", err
 	}
 
-	// Create message data
-	message := ClobAuthData{
-		Address:   address,
-		Timestamp: fmt.Sprintf("%d", timestamp),
-		Nonce:     nonce,
-		Message:   MSG_TO_SIGN,
-	}
+	typedData := BuildClobAuthTypedData(address, chainID, timestamp, nonce)
 
-	// Generate the sign hash
-	domainSeparator, err := getDomainSeparator(domain)
+	signature, err := SignTypedData(privateKey, typedData)
 	if err != nil {
-		return "", fmt.Errorf("failed to get domain separator: %w", err)
+		return "", fmt.Errorf("failed to sign ClobAuth typed data: %w", err)
 	}
 
-	typeHash, err := getTypeHash(types["ClobAuth"])
-	if err != nil {
-		return "", fmt.Errorf("failed to get type hash: %w", err)
-	}
+	return signature, nil
+}
 
-	encodeData, err := encodeClobAuthData(message)
+// SignTypedData signs an EIP-712 typed data payload and returns a 65-byte
+// r||s||v hex signature with v normalized to {27,28}, per the EIP-712/eth_signTypedData
+// convention used by Polymarket's CLOB and exchange contracts.
+func SignTypedData(privateKey *ecdsa.PrivateKey, typedData apitypes.TypedData) (string, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode data: %w", err)
+		return "", fmt.Errorf("failed to hash typed data: %w", err)
 	}
 
-	// Construct the final hash: keccak256("||" || domainSeparator || typeHash || encodeData)
-	hash := crypto.Keccak256Hash(
-		[]byte("\x19\x01"),
-		domainSeparator.Bytes(),
-		typeHash.Bytes(),
-		encodeData,
-	)
-
-	// Sign the hash
-	signature, err := crypto.Sign(hash.Bytes(), privateKey)
+	signature, err := crypto.Sign(hash, privateKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign hash: %w", err)
 	}
 
-	// Convert signature to hex string
-	signatureHex := hexutil.Encode(signature)
+	// go-ethereum returns v in {0,1}; the on-chain/off-chain EIP-712 convention
+	// Polymarket expects is v in {27,28}.
+	signature[64] += 27
 
-	return signatureHex, nil
+	return hexutil.Encode(signature), nil
 }
 
-// getDomainSeparator creates the domain separator hash
-func getDomainSeparator(domain EIP712Domain) (common.Hash, error) {
-	domainData, err := json.Marshal(domain)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to marshal domain: %w", err)
+// domainTypes builds the EIP712Domain type list matching the fields that
+// apitypes.TypedDataDomain.Map() actually emits, since apitypes requires the
+// declared "EIP712Domain" type to exactly mirror the populated domain fields.
+func domainTypes(name, version string, includeChainID, includeVerifyingContract, includeSalt bool) []apitypes.Type {
+	types := []apitypes.Type{}
+	if name != "" {
+		types = append(types, apitypes.Type{Name: "name", Type: "string"})
 	}
-
-	return crypto.Keccak256Hash(domainData), nil
-}
-
-// getTypeHash creates the type hash for ClobAuth
-func getTypeHash(types []EIP712Type) (common.Hash, error) {
-	typeString := "ClobAuth(" + "address,uint256,string,address" + ")"
-
-	// Actually, let's build the type string correctly
-	var typeStr string
-	for i, t := range types {
-		if i > 0 {
-			typeStr += ","
-		}
-		typeStr += t.Type + " " + t.Name
+	if version != "" {
+		types = append(types, apitypes.Type{Name: "version", Type: "string"})
 	}
-	typeString = "ClobAuth(" + typeStr + ")"
-
-	return crypto.Keccak256Hash([]byte(typeString)), nil
-}
-
-// encodeClobAuthData encodes the ClobAuth data according to EIP-712
-func encodeClobAuthData(data ClobAuthData) ([]byte, error) {
-	address := common.HexToAddress(data.Address)
-	nonce := new(big.Int).SetUint64(data.Nonce)
-	message := data.Message
-
-	// Create the encoded data
-	var encodedData []byte
-
-	// Encode address (padded to 32 bytes)
-	addressHash := crypto.Keccak256Hash(address.Bytes())
-	encodedData = append(encodedData, addressHash.Bytes()...)
-
-	// Encode timestamp as string
-	timestampHash := crypto.Keccak256Hash([]byte(data.Timestamp))
-	encodedData = append(encodedData, timestampHash.Bytes()...)
-
-	// Encode nonce
-	nonceBytes := nonce.Bytes()
-	paddedNonce := make([]byte, 32)
-	copy(paddedNonce[32-len(nonceBytes):], nonceBytes)
-	encodedData = append(encodedData, paddedNonce...)
-
-	// Encode message
-	messageHash := crypto.Keccak256Hash([]byte(message))
-	encodedData = append(encodedData, messageHash.Bytes()...)
-
-	return encodedData, nil
-}
-
-// SignTypedData signs EIP-712 typed data using the private key
-func SignTypedData(privateKey *ecdsa.PrivateKey, typedData TypedData) (string, error) {
-	// This is a more complete implementation that follows the EIP-712 spec exactly
-	hash, err := getTypedDataHash(typedData)
-	if err != nil {
-		return "", fmt.Errorf("failed to get typed data hash: %w", err)
-	}
-
-	// Sign the hash
-	signature, err := crypto.Sign(hash.Bytes(), privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign hash: %w", err)
+	if includeChainID {
+		types = append(types, apitypes.Type{Name: "chainId", Type: "uint256"})
 	}
-
-	// Convert to hex string
-	signatureHex := hexutil.Encode(signature)
-
-	return signatureHex, nil
-}
-
-// getTypedDataHash computes the hash of typed data according to EIP-712
-func getTypedDataHash(typedData TypedData) (common.Hash, error) {
-	// Hash the domain separator
-	domainSeparator, err := getDomainSeparator(typedData.Domain)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	// Hash the message
-	messageHash, err := getMessageHash(typedData)
-	if err != nil {
-		return common.Hash{}, err
+	if includeVerifyingContract {
+		types = append(types, apitypes.Type{Name: "verifyingContract", Type: "address"})
 	}
-
-	// Construct final hash: keccak256("||" || domainSeparator || messageHash)
-	finalHash := crypto.Keccak256Hash(
-		[]byte("\x19\x01"),
-		domainSeparator.Bytes(),
-		messageHash.Bytes(),
-	)
-
-	return finalHash, nil
-}
-
-// getMessageHash hashes the message part of typed data
-func getMessageHash(typedData TypedData) (common.Hash, error) {
-	// Convert message to bytes
-	messageBytes, err := json.Marshal(typedData.Message)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to marshal message: %w", err)
+	if includeSalt {
+		types = append(types, apitypes.Type{Name: "salt", Type: "string"})
 	}
-
-	return crypto.Keccak256Hash(messageBytes), nil
+	return types
 }
 
 // RecoverAddress recovers the address from a signature
@@ -236,9 +131,10 @@ func RecoverAddress(hash common.Hash, signature string) (common.Address, error)
 		return common.Address{}, fmt.Errorf("signature must be 65 bytes long")
 	}
 
-	// Adjust v value if needed (go-ethereum expects 27 or 28)
-	if sig[64] != 27 && sig[64] != 28 {
-		sig[64] += 27
+	// crypto.SigToPub expects v in {0,1}; normalize down from the {27,28} convention.
+	sig = append([]byte{}, sig...)
+	if sig[64] == 27 || sig[64] == 28 {
+		sig[64] -= 27
 	}
 
 	pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
@@ -246,6 +142,22 @@ func RecoverAddress(hash common.Hash, signature string) (common.Address, error)
 		return common.Address{}, fmt.Errorf("failed to recover public key: %w", err)
 	}
 
-	recoveredAddress := crypto.PubkeyToAddress(*pubkey)
-	return recoveredAddress, nil
-}
\ No newline at end of file
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+// VerifyTypedDataSignature reports whether signature was produced by address
+// signing typedData, so order/cancel/ClobAuth signatures can be checked
+// offline without a running CLOB client.
+func VerifyTypedDataSignature(address string, typedData apitypes.TypedData, signature string) (bool, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash typed data: %w", err)
+	}
+
+	recovered, err := RecoverAddress(common.BytesToHash(hash), signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	return strings.EqualFold(recovered.Hex(), address), nil
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gomath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// testPrivateKeyHex is a fixed, non-production private key used only to make
+// these tests deterministic.
+const testPrivateKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// These expected hashes are golden values, cross-checked two ways below: once
+// via go-ethereum's apitypes.TypedDataAndHash (the code path BuildClobAuthTypedData
+// and BuildOrderTypedData actually feed), and once via independentEIP712Hash, a
+// hand-rolled encoder reimplemented directly from the EIP-712 spec rather than
+// sharing any code with apitypes. A regression in the domain separator or struct
+// hash -- e.g. a dropped field, a wrong type string -- would change one of these
+// two encodings without the other, which self-pinned hashes from a single code
+// path can't detect. Pinned on both Polygon mainnet (137) and the Mumbai testnet
+// (80001).
+func TestTypedDataHashesAreStable(t *testing.T) {
+	priv, err := crypto.HexToECDSA(testPrivateKeyHex)
+	if err != nil {
+		t.Fatalf("failed to load test private key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(priv.PublicKey).Hex()
+
+	order := OrderComponents{
+		Salt:          "1",
+		Maker:         address,
+		Signer:        address,
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "123",
+		MakerAmount:   "1000000",
+		TakerAmount:   "2000000",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "0",
+		Side:          0,
+		SignatureType: 0,
+	}
+	const verifyingContract = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+
+	cases := []struct {
+		name         string
+		typedData    apitypes.TypedData
+		expectedHash string
+	}{
+		{
+			name:         "ClobAuth/Polygon",
+			typedData:    BuildClobAuthTypedData(address, 137, 1700000000, 1),
+			expectedHash: "0xadb866db5161b5d0371c92e4bdfab4e51d9b3fa17bf5818405cb6f80a7160e9d",
+		},
+		{
+			name:         "ClobAuth/Mumbai",
+			typedData:    BuildClobAuthTypedData(address, 80001, 1700000000, 1),
+			expectedHash: "0xaf8abe756d9c4375c4016472e9875afc6bace4b4b5e3d2ef0746539375036c95",
+		},
+		{
+			name:         "Order/Polygon",
+			typedData:    BuildOrderTypedData(order, 137, verifyingContract),
+			expectedHash: "0x5ee4b4cafcf9fa9f76487845537569e5a0a7eefcc8105ac51fe79e6081f3438f",
+		},
+		{
+			name:         "Order/Mumbai",
+			typedData:    BuildOrderTypedData(order, 80001, verifyingContract),
+			expectedHash: "0x2b1c30b8646c8b75bf7d186a33a6d84e49c7f8080de2d507040707f28595bbf0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, _, err := apitypes.TypedDataAndHash(tc.typedData)
+			if err != nil {
+				t.Fatalf("TypedDataAndHash failed: %v", err)
+			}
+			if got := hexutil.Encode(hash); got != tc.expectedHash {
+				t.Fatalf("hash changed: got %s, want %s", got, tc.expectedHash)
+			}
+
+			independent, err := independentEIP712Hash(tc.typedData)
+			if err != nil {
+				t.Fatalf("independentEIP712Hash failed: %v", err)
+			}
+			if got := hexutil.Encode(independent); got != tc.expectedHash {
+				t.Fatalf("apitypes and the independent encoder disagree: got %s, want %s", got, tc.expectedHash)
+			}
+
+			signature, err := SignTypedData(priv, tc.typedData)
+			if err != nil {
+				t.Fatalf("SignTypedData failed: %v", err)
+			}
+
+			ok, err := VerifyTypedDataSignature(address, tc.typedData, signature)
+			if err != nil {
+				t.Fatalf("VerifyTypedDataSignature failed: %v", err)
+			}
+			if !ok {
+				t.Fatalf("expected signature to verify against %s", address)
+			}
+
+			if ok, _ := VerifyTypedDataSignature("0x0000000000000000000000000000000000000001", tc.typedData, signature); ok {
+				t.Fatalf("expected signature not to verify against an unrelated address")
+			}
+		})
+	}
+}
+
+// independentEIP712Hash computes the EIP-712 digest (keccak256("\x19\x01" ||
+// domainSeparator || structHash)) directly from the spec, supporting only the
+// "string", "address", "uint256", and "uint8" field types this package's
+// typed-data builders use. It intentionally does not call into apitypes, so
+// it can catch an encoding bug apitypes itself wouldn't.
+func independentEIP712Hash(td apitypes.TypedData) ([]byte, error) {
+	domainFields, ok := td.Types["EIP712Domain"]
+	if !ok {
+		return nil, fmt.Errorf("missing EIP712Domain type")
+	}
+	domainValues := map[string]interface{}{
+		"name":    td.Domain.Name,
+		"version": td.Domain.Version,
+	}
+	if td.Domain.ChainId != nil {
+		domainValues["chainId"] = (*big.Int)(td.Domain.ChainId)
+	}
+	if td.Domain.VerifyingContract != "" {
+		domainValues["verifyingContract"] = td.Domain.VerifyingContract
+	}
+
+	domainSeparator, err := independentEncodeData("EIP712Domain", domainFields, domainValues)
+	if err != nil {
+		return nil, fmt.Errorf("domain separator: %w", err)
+	}
+
+	primaryFields, ok := td.Types[td.PrimaryType]
+	if !ok {
+		return nil, fmt.Errorf("missing %s type", td.PrimaryType)
+	}
+	structHash, err := independentEncodeData(td.PrimaryType, primaryFields, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("struct hash: %w", err)
+	}
+
+	digest := append([]byte{0x19, 0x01}, domainSeparator...)
+	digest = append(digest, structHash...)
+	return crypto.Keccak256(digest), nil
+}
+
+func independentEncodeData(name string, fields []apitypes.Type, values map[string]interface{}) ([]byte, error) {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.Type + " " + f.Name
+	}
+	encoded := crypto.Keccak256([]byte(name + "(" + strings.Join(parts, ",") + ")"))
+
+	for _, f := range fields {
+		value, err := independentEncodeValue(f.Type, values[f.Name])
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		encoded = append(encoded, value...)
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+func independentEncodeValue(fieldType string, value interface{}) ([]byte, error) {
+	switch fieldType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+
+	case "address":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(s).Bytes(), 32), nil
+
+	case "uint256", "uint8":
+		var n *big.Int
+		switch v := value.(type) {
+		case string:
+			n = new(big.Int)
+			if _, ok := n.SetString(v, 10); !ok {
+				return nil, fmt.Errorf("invalid integer %q", v)
+			}
+		case *big.Int:
+			n = v
+		case *gomath.HexOrDecimal256:
+			n = (*big.Int)(v)
+		default:
+			return nil, fmt.Errorf("unsupported value type %T for %s", value, fieldType)
+		}
+		return common.LeftPadBytes(n.Bytes(), 32), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported field type %s", fieldType)
+	}
+}
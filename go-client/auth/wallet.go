@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Wallet wraps an ECDSA private key with the signing operations Polymarket's
+// CLOB and CTF Exchange contracts expect, so auth payloads and orders can be
+// produced fully offline without a running CLOB client.
+type Wallet struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewWalletFromHex creates a Wallet from a hex-encoded ECDSA private key,
+// with or without a "0x" prefix.
+func NewWalletFromHex(privateKeyHex string) (*Wallet, error) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return &Wallet{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}, nil
+}
+
+// GetPrivateKey returns the wallet's underlying private key.
+func (w *Wallet) GetPrivateKey() *ecdsa.PrivateKey {
+	return w.privateKey
+}
+
+// GetAddress returns the wallet's address.
+func (w *Wallet) GetAddress() common.Address {
+	return w.address
+}
+
+// GetAddressHex returns the wallet's checksummed address as a hex string.
+func (w *Wallet) GetAddressHex() string {
+	return w.address.Hex()
+}
+
+// SignMessage signs an arbitrary message using the EIP-191 "personal_sign"
+// convention (prefixing it with "\x19Ethereum Signed Message:\n<len>" before
+// hashing) and returns a 65-byte r||s||v hex signature with v normalized to
+// {27,28}.
+func (w *Wallet) SignMessage(message []byte) (string, error) {
+	if err := CheckAddressBlocked(w.address.Hex()); err != nil {
+		return "", err
+	}
+
+	hash := accounts.TextHash(message)
+	signature, err := crypto.Sign(hash, w.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	signature[64] += 27
+	return hexutil.Encode(signature), nil
+}
+
+// VerifyMessageSignature reports whether signature was produced by address
+// signing message via the EIP-191 "personal_sign" convention.
+func VerifyMessageSignature(message []byte, signature string, address common.Address) (bool, error) {
+	recovered, err := RecoverAddress(common.BytesToHash(accounts.TextHash(message)), signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to recover signer: %w", err)
+	}
+
+	return strings.EqualFold(recovered.Hex(), address.Hex()), nil
+}
+
+// SignedOrder is a Polymarket CLOB order augmented with the EIP-712 signature
+// produced over it, ready to submit to the CLOB's order-placement endpoint.
+type SignedOrder struct {
+	OrderComponents
+	Signature string `json:"signature"`
+}
+
+// SignOrder builds the EIP-712 typed data for order against the CTF Exchange
+// contract at verifyingContract on chainID, signs it with the wallet's
+// private key, and returns the resulting SignedOrder ready to submit to the
+// CLOB without a running client.
+func (w *Wallet) SignOrder(order OrderComponents, chainID int64, verifyingContract string) (SignedOrder, error) {
+	if err := CheckAddressBlocked(w.address.Hex()); err != nil {
+		return SignedOrder{}, err
+	}
+
+	typedData := BuildOrderTypedData(order, chainID, verifyingContract)
+
+	signature, err := SignTypedData(w.privateKey, typedData)
+	if err != nil {
+		return SignedOrder{}, fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	return SignedOrder{OrderComponents: order, Signature: signature}, nil
+}
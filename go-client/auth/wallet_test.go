@@ -0,0 +1,75 @@
+package auth
+
+import "testing"
+
+func TestWalletSignAndVerifyMessage(t *testing.T) {
+	wallet, err := NewWalletFromHex(testPrivateKeyHex)
+	if err != nil {
+		t.Fatalf("NewWalletFromHex failed: %v", err)
+	}
+
+	message := []byte("Hello, Polymarket!")
+	signature, err := wallet.SignMessage(message)
+	if err != nil {
+		t.Fatalf("SignMessage failed: %v", err)
+	}
+
+	ok, err := VerifyMessageSignature(message, signature, wallet.GetAddress())
+	if err != nil {
+		t.Fatalf("VerifyMessageSignature failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected signature to verify against the signing wallet")
+	}
+
+	other, err := NewWalletFromHex("17c4e0ee8d4772c98420d9a53a3ad08fcdb5818f7b34da5bda3d04618b6949a5")
+	if err != nil {
+		t.Fatalf("NewWalletFromHex failed: %v", err)
+	}
+	if ok, _ := VerifyMessageSignature(message, signature, other.GetAddress()); ok {
+		t.Fatal("expected signature not to verify against an unrelated wallet")
+	}
+}
+
+func TestWalletSignOrder(t *testing.T) {
+	wallet, err := NewWalletFromHex(testPrivateKeyHex)
+	if err != nil {
+		t.Fatalf("NewWalletFromHex failed: %v", err)
+	}
+
+	order := OrderComponents{
+		Salt:          "1",
+		Maker:         wallet.GetAddressHex(),
+		Signer:        wallet.GetAddressHex(),
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       "123",
+		MakerAmount:   "1000000",
+		TakerAmount:   "2000000",
+		Expiration:    "0",
+		Nonce:         "0",
+		FeeRateBps:    "0",
+		Side:          0,
+		SignatureType: 0,
+	}
+	const verifyingContract = "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"
+
+	signed, err := wallet.SignOrder(order, 137, verifyingContract)
+	if err != nil {
+		t.Fatalf("SignOrder failed: %v", err)
+	}
+	if signed.Signature == "" {
+		t.Fatal("expected a non-empty signature")
+	}
+	if signed.OrderComponents != order {
+		t.Fatalf("expected SignedOrder to embed the original order unchanged, got %+v", signed.OrderComponents)
+	}
+
+	typedData := BuildOrderTypedData(order, 137, verifyingContract)
+	ok, err := VerifyTypedDataSignature(wallet.GetAddressHex(), typedData, signed.Signature)
+	if err != nil {
+		t.Fatalf("VerifyTypedDataSignature failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected SignOrder's signature to verify against the wallet's address")
+	}
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+)
+
+// ClobClient is an HTTP client for Polymarket's CLOB REST API. Its HTTP
+// layer is built from the HTTPDoer interface (NewNetHTTPDoer or
+// NewFastHTTPDoer, selected via ClientConfig.HTTPBackend) wrapped with retry
+// and, if configured, logging middleware, so the transport is swappable
+// without touching the methods below.
+//
+// ClobClient currently only implements the CLOB's public, unauthenticated
+// endpoints. Authenticated endpoints (API key management, trades) need L1/L2
+// request signing this package doesn't yet provide, and are out of scope
+// here.
+type ClobClient struct {
+	host   string
+	config ClientConfig
+	doer   HTTPDoer
+}
+
+// NewClobClient builds a ClobClient from config, applying ClientConfig's
+// defaults for Timeout, DialTimeout, HTTPBackend, MaxRetries, and
+// RetryBackoff.
+func NewClobClient(config *ClientConfig) (*ClobClient, error) {
+	if config == nil {
+		return nil, fmt.Errorf("client config is required")
+	}
+	if config.Host == "" {
+		return nil, fmt.Errorf("client config: Host is required")
+	}
+
+	if config.PrivateKey != "" {
+		wallet, err := auth.NewWalletFromHex(config.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("client config: invalid PrivateKey: %w", err)
+		}
+		address := wallet.GetAddressHex()
+
+		if config.OFACPolicy != nil {
+			if err := config.OFACPolicy.CheckBlocked(address); err != nil {
+				return nil, fmt.Errorf("client config: %w", err)
+			}
+		} else if err := auth.CheckAddressBlocked(address); err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	dialTimeout := config.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = DefaultDialTimeout
+	}
+
+	resolved := *config
+	resolved.Timeout = timeout
+	resolved.DialTimeout = dialTimeout
+
+	return &ClobClient{
+		host:   config.Host,
+		config: resolved,
+		doer:   buildDoer(&resolved, dialTimeout),
+	}, nil
+}
+
+func (c *ClobClient) get(ctx context.Context, path string, query map[string]string, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	u, err := url.Parse(c.host + path)
+	if err != nil {
+		return fmt.Errorf("failed to build request URL: %w", err)
+	}
+	if len(query) > 0 {
+		q := u.Query()
+		for k, v := range query {
+			q.Set(k, v)
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req := &Request{
+		Method: http.MethodGet,
+		URL:    u.String(),
+		Header: http.Header{"Accept": []string{"application/json"}},
+	}
+	resp, err := c.doer.Do(ctx, req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", http.MethodGet, path, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", http.MethodGet, path, resp.StatusCode, string(resp.Body))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("%s %s: failed to decode response: %w", http.MethodGet, path, err)
+	}
+	return nil
+}
+
+// GetServerTime returns the CLOB's current server time, used to build
+// signing timestamps when ClientConfig.UseServerTime is set.
+func (c *ClobClient) GetServerTime() (int64, error) {
+	var raw json.Number
+	if err := c.get(context.Background(), "/time", nil, &raw); err != nil {
+		return 0, err
+	}
+	t, err := raw.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse server time %q: %w", raw, err)
+	}
+	return t, nil
+}
+
+// GetOK reports whether the CLOB API is reachable and healthy.
+func (c *ClobClient) GetOK() (bool, error) {
+	var body struct {
+		Ok bool `json:"ok"`
+	}
+	if err := c.get(context.Background(), "/ok", nil, &body); err != nil {
+		return false, err
+	}
+	return body.Ok, nil
+}
+
+// MarketsResponse is the paginated response returned by GetMarkets.
+type MarketsResponse struct {
+	Count      int               `json:"count"`
+	NextCursor string            `json:"next_cursor"`
+	Data       []json.RawMessage `json:"data"`
+}
+
+// GetMarkets lists CLOB markets, paginated via nextCursor ("" for the first page).
+func (c *ClobClient) GetMarkets(nextCursor string) (*MarketsResponse, error) {
+	query := map[string]string{}
+	if nextCursor != "" {
+		query["next_cursor"] = nextCursor
+	}
+
+	var resp MarketsResponse
+	if err := c.get(context.Background(), "/markets", query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTickSize returns the minimum tick size for tokenID.
+func (c *ClobClient) GetTickSize(tokenID string) (string, error) {
+	var body struct {
+		MinimumTickSize string `json:"minimum_tick_size"`
+	}
+	if err := c.get(context.Background(), "/tick-size", map[string]string{"token_id": tokenID}, &body); err != nil {
+		return "", err
+	}
+	return body.MinimumTickSize, nil
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+)
+
+// testPrivateKey is Hardhat's well-known default account #0; its address is
+// fixed and known (testBlockedAddress below), so it's safe to hardcode here.
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+const testBlockedAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+// stubBlocklistSource is a fixed-list auth.BlocklistSource for tests.
+type stubBlocklistSource struct {
+	addresses []string
+}
+
+func (s stubBlocklistSource) Load(ctx context.Context) ([]string, error) {
+	return s.addresses, nil
+}
+
+func TestNewClobClientRejectsBlockedAddress(t *testing.T) {
+	policy := auth.NewBlocklistChecker(stubBlocklistSource{addresses: []string{testBlockedAddress}}, 0)
+	if err := policy.Start(context.Background()); err != nil {
+		t.Fatalf("policy.Start failed: %v", err)
+	}
+
+	_, err := NewClobClient(&ClientConfig{
+		Host:       "https://clob.polymarket.com",
+		PrivateKey: testPrivateKey,
+		OFACPolicy: policy,
+	})
+	if err == nil {
+		t.Fatal("expected NewClobClient to reject a blocked address, got nil error")
+	}
+	if !strings.Contains(err.Error(), "sanctioned-address blocklist") {
+		t.Fatalf("expected a blocklist error, got: %v", err)
+	}
+}
+
+func TestNewClobClientAllowsUnblockedAddress(t *testing.T) {
+	policy := auth.NewBlocklistChecker(stubBlocklistSource{addresses: []string{"0x000000000000000000000000000000000000dead"}}, 0)
+	if err := policy.Start(context.Background()); err != nil {
+		t.Fatalf("policy.Start failed: %v", err)
+	}
+
+	client, err := NewClobClient(&ClientConfig{
+		Host:       "https://clob.polymarket.com",
+		PrivateKey: testPrivateKey,
+		OFACPolicy: policy,
+	})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+	if client == nil {
+		t.Fatal("expected a non-nil client")
+	}
+}
+
+func TestNewClobClientAllowsMissingPrivateKey(t *testing.T) {
+	client, err := NewClobClient(&ClientConfig{Host: "https://clob.polymarket.com"})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+	if client == nil {
+		t.Fatal("expected a non-nil client")
+	}
+}
+
+func TestClobClientGetServerTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/time" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte("1700000000"))
+	}))
+	defer server.Close()
+
+	client, err := NewClobClient(&ClientConfig{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+
+	serverTime, err := client.GetServerTime()
+	if err != nil {
+		t.Fatalf("GetServerTime failed: %v", err)
+	}
+	if serverTime != 1700000000 {
+		t.Fatalf("unexpected server time: %d", serverTime)
+	}
+}
+
+func TestClobClientGetOK(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClobClient(&ClientConfig{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+
+	ok, err := client.GetOK()
+	if err != nil {
+		t.Fatalf("GetOK failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected GetOK to report true")
+	}
+}
+
+func TestClobClientGetMarketsPaginates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/markets" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if cursor := r.URL.Query().Get("next_cursor"); cursor != "abc" {
+			t.Errorf("expected next_cursor=abc, got %q", cursor)
+		}
+		w.Write([]byte(`{"count":1,"next_cursor":"def","data":[{"condition_id":"0x1"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClobClient(&ClientConfig{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+
+	resp, err := client.GetMarkets("abc")
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if resp.Count != 1 || resp.NextCursor != "def" || len(resp.Data) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestClobClientGetTickSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tick-size" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if tokenID := r.URL.Query().Get("token_id"); tokenID != "token-1" {
+			t.Errorf("expected token_id=token-1, got %q", tokenID)
+		}
+		w.Write([]byte(`{"minimum_tick_size":"0.01"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClobClient(&ClientConfig{Host: server.URL})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+
+	tickSize, err := client.GetTickSize("token-1")
+	if err != nil {
+		t.Fatalf("GetTickSize failed: %v", err)
+	}
+	if tickSize != "0.01" {
+		t.Fatalf("unexpected tick size: %q", tickSize)
+	}
+}
+
+func TestClobClientGetMarketsSurfacesServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client, err := NewClobClient(&ClientConfig{Host: server.URL, MaxRetries: 1, RetryBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewClobClient failed: %v", err)
+	}
+
+	_, err = client.GetMarkets("")
+	if err == nil {
+		t.Fatal("expected GetMarkets to surface the server's error status")
+	}
+	if !strings.Contains(err.Error(), "500") {
+		t.Fatalf("expected the error to mention the status code, got: %v", err)
+	}
+}
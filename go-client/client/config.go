@@ -0,0 +1,88 @@
+package client
+
+import (
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/auth"
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// DefaultTimeout bounds the total time allowed for a single logical request,
+// including any retries.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultDialTimeout bounds establishing the underlying TCP/TLS connection,
+// separate from DefaultTimeout.
+const DefaultDialTimeout = 10 * time.Second
+
+// ClientConfig configures a ClobClient.
+type ClientConfig struct {
+	// Host is the CLOB API base URL, e.g. "https://clob.polymarket.com".
+	Host string
+
+	// ChainID identifies which chain orders and auth payloads are signed for.
+	ChainID types.Chain
+
+	// PrivateKey is the hex-encoded signing key used for L1/L2 auth headers.
+	PrivateKey string
+
+	// APIKey, if set, is used for authenticated (L2) endpoints.
+	APIKey *types.ApiKeyCreds
+
+	// UseServerTime, if true, fetches the CLOB's server time for signing
+	// timestamps instead of using the local clock.
+	UseServerTime bool
+
+	// Timeout bounds the total time allowed for a single logical request,
+	// including retries. Defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// DialTimeout bounds establishing the underlying TCP/TLS connection,
+	// separate from Timeout. Defaults to DefaultDialTimeout.
+	DialTimeout time.Duration
+
+	// HTTPBackend selects the underlying HTTP implementation. Defaults to
+	// types.HTTPBackendNetHTTP.
+	HTTPBackend types.HTTPBackend
+
+	// MaxRetries bounds how many times a request that failed with a 429 or
+	// 5xx response is retried. Defaults to DefaultMaxRetries.
+	MaxRetries int
+
+	// RetryBackoff is the base delay between retries. Defaults to
+	// DefaultRetryBackoff.
+	RetryBackoff time.Duration
+
+	// OnRequest, if set, is called immediately before each HTTP request.
+	OnRequest func(types.RequestLogEntry)
+
+	// OnResponse, if set, is called immediately after each HTTP request
+	// completes, whether it succeeded or not.
+	OnResponse func(types.RequestLogEntry)
+
+	// OFACPolicy, if set, rejects NewClobClient when the address derived
+	// from PrivateKey is on its blocklist. If nil, NewClobClient instead
+	// consults the process-wide checker installed via
+	// auth.SetBlocklistChecker, if any. Use a *auth.BlocklistChecker built
+	// with auth.NewBlocklistChecker (and Start'd) to control the refresh
+	// interval and source per client instead of process-wide.
+	OFACPolicy *auth.BlocklistChecker
+}
+
+// buildDoer assembles config's HTTP backend with retry and (if configured)
+// logging middleware layered on top.
+func buildDoer(config *ClientConfig, dialTimeout time.Duration) HTTPDoer {
+	var backend HTTPDoer
+	switch config.HTTPBackend {
+	case types.HTTPBackendFastHTTP:
+		backend = NewFastHTTPDoer(dialTimeout)
+	default:
+		backend = NewNetHTTPDoer(dialTimeout)
+	}
+
+	doer := HTTPDoer(NewRetryingDoer(backend, config.MaxRetries, config.RetryBackoff))
+	if config.OnRequest != nil || config.OnResponse != nil {
+		doer = NewLoggingDoer(doer, config.OnRequest, config.OnResponse)
+	}
+	return doer
+}
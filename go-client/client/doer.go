@@ -0,0 +1,32 @@
+// Package client implements a CLOB REST API client whose HTTP transport is
+// swappable behind the HTTPDoer interface, with retry and logging behavior
+// layered on top as middleware rather than baked into the transport itself.
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// HTTPDoer is the interface ClobClient's HTTP layer is built against, so the
+// underlying transport (net/http, fasthttp, ...) can be swapped via
+// ClientConfig.HTTPBackend without touching call sites, and so the same
+// retry/logging middleware wraps either implementation identically.
+type HTTPDoer interface {
+	Do(ctx context.Context, req *Request) (*Response, error)
+}
+
+// Request is a backend-agnostic HTTP request.
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// Response is a backend-agnostic HTTP response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// FastHTTPDoer implements HTTPDoer using valyala/fasthttp, trading net/http's
+// simplicity for lower per-request allocations under high request rates.
+type FastHTTPDoer struct {
+	client *fasthttp.Client
+}
+
+// NewFastHTTPDoer creates a FastHTTPDoer whose dial is bounded by dialTimeout.
+func NewFastHTTPDoer(dialTimeout time.Duration) *FastHTTPDoer {
+	return &FastHTTPDoer{
+		client: &fasthttp.Client{
+			Dial: func(addr string) (net.Conn, error) {
+				return fasthttp.DialTimeout(addr, dialTimeout)
+			},
+		},
+	}
+}
+
+// Do implements HTTPDoer.
+func (d *FastHTTPDoer) Do(ctx context.Context, req *Request) (*Response, error) {
+	httpReq := fasthttp.AcquireRequest()
+	httpResp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(httpReq)
+	defer fasthttp.ReleaseResponse(httpResp)
+
+	httpReq.SetRequestURI(req.URL)
+	httpReq.Header.SetMethod(req.Method)
+	for key, values := range req.Header {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+	if len(httpReq.Header.Peek("Accept-Encoding")) == 0 {
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
+	if req.Body != nil {
+		httpReq.SetBody(req.Body)
+	}
+
+	var err error
+	if deadline, ok := ctx.Deadline(); ok {
+		err = d.client.DoDeadline(httpReq, httpResp, deadline)
+	} else {
+		err = d.client.Do(httpReq, httpResp)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	body := httpResp.Body()
+	if string(httpResp.Header.Peek("Content-Encoding")) == "gzip" {
+		decompressed, err := httpResp.BodyGunzip()
+		if err != nil {
+			return nil, fmt.Errorf("failed to gunzip response: %w", err)
+		}
+		body = decompressed
+	}
+	// httpResp is released above; copy its body out before that happens.
+	bodyCopy := append([]byte(nil), body...)
+
+	header := make(http.Header)
+	httpResp.Header.VisitAll(func(k, v []byte) {
+		header.Add(string(k), string(v))
+	})
+
+	return &Response{StatusCode: httpResp.StatusCode(), Header: header, Body: bodyCopy}, nil
+}
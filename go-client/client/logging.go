@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+// LoggingDoer wraps another HTTPDoer, invoking onRequest immediately before
+// each attempt and onResponse immediately after it completes, so a caller can
+// observe method, path, latency, and status without instrumenting every call
+// site. It wraps a RetryingDoer (rather than the reverse), so its hooks see
+// the outcome of the whole logical request, retries included, not each
+// individual attempt.
+type LoggingDoer struct {
+	next       HTTPDoer
+	onRequest  func(types.RequestLogEntry)
+	onResponse func(types.RequestLogEntry)
+}
+
+// NewLoggingDoer wraps next, calling onRequest/onResponse around each Do.
+// Either hook may be nil.
+func NewLoggingDoer(next HTTPDoer, onRequest, onResponse func(types.RequestLogEntry)) *LoggingDoer {
+	return &LoggingDoer{next: next, onRequest: onRequest, onResponse: onResponse}
+}
+
+// Do implements HTTPDoer.
+func (d *LoggingDoer) Do(ctx context.Context, req *Request) (*Response, error) {
+	path := requestPath(req.URL)
+
+	if d.onRequest != nil {
+		d.onRequest(types.RequestLogEntry{Method: req.Method, Path: path})
+	}
+
+	start := time.Now()
+	resp, err := d.next.Do(ctx, req)
+
+	entry := types.RequestLogEntry{
+		Method:  req.Method,
+		Path:    path,
+		Latency: time.Since(start),
+		Err:     err,
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+	}
+	if d.onResponse != nil {
+		d.onResponse(entry)
+	}
+
+	return resp, err
+}
+
+// requestPath returns rawURL's path component, falling back to rawURL itself
+// if it doesn't parse, so a malformed URL still produces a usable log entry.
+func requestPath(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Path
+}
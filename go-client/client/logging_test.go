@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/types"
+)
+
+func TestLoggingDoerInvokesOnRequestAndOnResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var requested, responded types.RequestLogEntry
+	doer := NewLoggingDoer(NewNetHTTPDoer(time.Second),
+		func(e types.RequestLogEntry) { requested = e },
+		func(e types.RequestLogEntry) { responded = e },
+	)
+
+	resp, err := doer.Do(context.Background(), &Request{
+		Method: http.MethodGet,
+		URL:    server.URL + "/markets",
+		Header: http.Header{},
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+
+	if requested.Method != http.MethodGet || requested.Path != "/markets" {
+		t.Fatalf("unexpected onRequest entry: %+v", requested)
+	}
+	if responded.Status != http.StatusOK || responded.Path != "/markets" || responded.Err != nil {
+		t.Fatalf("unexpected onResponse entry: %+v", responded)
+	}
+}
+
+func TestLoggingDoerReportsErrInOnResponse(t *testing.T) {
+	var responded types.RequestLogEntry
+	doer := NewLoggingDoer(NewNetHTTPDoer(time.Second), nil, func(e types.RequestLogEntry) { responded = e })
+
+	_, err := doer.Do(context.Background(), &Request{
+		Method: http.MethodGet,
+		URL:    "http://127.0.0.1:0",
+		Header: http.Header{},
+	})
+	if err == nil {
+		t.Fatal("expected Do to fail against an unreachable URL")
+	}
+	if responded.Err == nil {
+		t.Fatal("expected onResponse entry to carry the error")
+	}
+}
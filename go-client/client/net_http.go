@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NetHTTPDoer implements HTTPDoer using Go's standard net/http client. It is
+// the default backend.
+type NetHTTPDoer struct {
+	client *http.Client
+}
+
+// NewNetHTTPDoer creates a NetHTTPDoer whose dial and TLS handshake are
+// bounded by dialTimeout, independent of the per-request timeout a caller
+// applies via ctx.
+func NewNetHTTPDoer(dialTimeout time.Duration) *NetHTTPDoer {
+	transport := &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: dialTimeout}).DialContext,
+		TLSHandshakeTimeout: dialTimeout,
+	}
+	return &NetHTTPDoer{client: &http.Client{Transport: transport}}
+}
+
+// Do implements HTTPDoer.
+func (d *NetHTTPDoer) Do(ctx context.Context, req *Request) (*Response, error) {
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader(req.Body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, req.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	httpReq.Header = req.Header.Clone()
+	if httpReq.Header.Get("Accept-Encoding") == "" {
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	resp, err := d.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip response: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	bodyBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: bodyBytes}, nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNetHTTPDoerDecompressesGzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"ok":true}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	doer := NewNetHTTPDoer(time.Second)
+	resp, err := doer.Do(context.Background(), &Request{Method: http.MethodGet, URL: server.URL, Header: http.Header{}})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if string(resp.Body) != `{"ok":true}` {
+		t.Fatalf("expected decompressed body, got %q", resp.Body)
+	}
+}
+
+func TestNetHTTPDoerPassesThroughPlainResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	doer := NewNetHTTPDoer(time.Second)
+	resp, err := doer.Do(context.Background(), &Request{Method: http.MethodGet, URL: server.URL, Header: http.Header{}})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if !bytes.Equal(resp.Body, []byte(`{"ok":true}`)) {
+		t.Fatalf("expected plain body, got %q", resp.Body)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxRetries is how many times RetryingDoer retries a request that
+// failed with a 429 or 5xx response.
+const DefaultMaxRetries = 3
+
+// DefaultRetryBackoff is the base delay between retries, doubled on each
+// subsequent attempt when the response carries no Retry-After header.
+const DefaultRetryBackoff = 250 * time.Millisecond
+
+// RetryingDoer wraps another HTTPDoer, retrying requests that come back with
+// a 429 or 5xx status (or fail outright). It honors a response's Retry-After
+// header (delay-seconds or an HTTP-date, per RFC 7231) when present, falling
+// back to exponential backoff otherwise.
+type RetryingDoer struct {
+	next       HTTPDoer
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewRetryingDoer wraps next with retry behavior. maxRetries <= 0 defaults to
+// DefaultMaxRetries; backoff <= 0 defaults to DefaultRetryBackoff.
+func NewRetryingDoer(next HTTPDoer, maxRetries int, backoff time.Duration) *RetryingDoer {
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	return &RetryingDoer{next: next, maxRetries: maxRetries, backoff: backoff}
+}
+
+// Do implements HTTPDoer.
+func (d *RetryingDoer) Do(ctx context.Context, req *Request) (*Response, error) {
+	var lastErr error
+	var lastResp *Response
+
+	for attempt := 0; attempt <= d.maxRetries; attempt++ {
+		resp, err := d.next.Do(ctx, req)
+		switch {
+		case err != nil:
+			lastErr, lastResp = err, nil
+		case isRetryableStatus(resp.StatusCode):
+			lastErr, lastResp = nil, resp
+		default:
+			return resp, nil
+		}
+
+		if attempt == d.maxRetries {
+			break
+		}
+
+		wait := retryAfterDelay(lastResp)
+		if wait <= 0 {
+			wait = d.backoff * time.Duration(1<<uint(attempt))
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return lastResp, nil
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfterDelay parses resp's Retry-After header, returning 0 if absent or
+// unparsable.
+func retryAfterDelay(resp *Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryingDoerRetriesOnServerErrorThenSucceeds(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	doer := NewRetryingDoer(NewNetHTTPDoer(time.Second), 5, time.Millisecond)
+	resp, err := doer.Do(context.Background(), &Request{Method: http.MethodGet, URL: server.URL, Header: http.Header{}})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryingDoerHonorsRetryAfterHeader(t *testing.T) {
+	var attempts int
+	var firstAttemptAt, secondAttemptAt time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			firstAttemptAt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		secondAttemptAt = time.Now()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	doer := NewRetryingDoer(NewNetHTTPDoer(time.Second), 1, time.Millisecond)
+	resp, err := doer.Do(context.Background(), &Request{Method: http.MethodGet, URL: server.URL, Header: http.Header{}})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	if gap := secondAttemptAt.Sub(firstAttemptAt); gap < 900*time.Millisecond {
+		t.Fatalf("expected the retry to wait out the 1s Retry-After, only waited %v", gap)
+	}
+}
+
+func TestRetryingDoerGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	doer := NewRetryingDoer(NewNetHTTPDoer(time.Second), 2, time.Millisecond)
+	resp, err := doer.Do(context.Background(), &Request{Method: http.MethodGet, URL: server.URL, Header: http.Header{}})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("unexpected status: %d", resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 1 initial attempt + 2 retries = 3, got %d", attempts)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/HuakunShen/polymarket-kit/go-client/auth"
 	"github.com/HuakunShen/polymarket-kit/go-client/client"
@@ -23,7 +24,7 @@ func main() {
 		PrivateKey:    privateKey,
 		APIKey:        apiCreds,
 		UseServerTime: true,
-		Timeout:       30 * 0, // 30 seconds timeout
+		Timeout:       30 * time.Second,
 	}
 
 	// Create CLOB client
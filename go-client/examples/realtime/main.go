@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -98,7 +99,7 @@ func onMessage(client *realtime.RealTimeDataClient, message realtime.Message) {
 func onConnect(client *realtime.RealTimeDataClient) {
 	log.Println("Connected!")
 	// Subscribe to various clob_market types to demonstrate payload parsing
-	client.Subscribe(realtime.SubscriptionMessage{
+	if err := client.Subscribe(context.Background(), realtime.SubscriptionMessage{
 		Subscriptions: []realtime.Subscription{
 			// Subscribe to all clob_market types to see different payload structures
 			{
@@ -132,7 +133,9 @@ func onConnect(client *realtime.RealTimeDataClient) {
 			// 	Type:  "market_resolved",
 			// },
 		},
-	})
+	}); err != nil {
+		log.Printf("subscribe error: %v", err)
+	}
 }
 
 func main() {
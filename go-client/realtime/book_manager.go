@@ -0,0 +1,554 @@
+package realtime
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBookNotifyBufferSize is the default size of each asset's change-notification channel.
+const DefaultBookNotifyBufferSize = 16
+
+// Book is a point-in-time snapshot of a local L2 order book for a single asset.
+type Book struct {
+	Market       string
+	AssetID      string
+	TickSize     string
+	MinOrderSize string
+	NegRisk      bool
+	Bids         []OrderBookLevel // sorted descending by price
+	Asks         []OrderBookLevel // sorted ascending by price
+}
+
+// BookUpdate is sent on a BookManager's per-asset notification channel whenever
+// that asset's book changes.
+type BookUpdate struct {
+	AssetID string
+	Seq     uint64
+}
+
+// BookHashFunc computes a continuity digest over a book's current state, so
+// BookManager can tell whether applying a delta reproduced the state the
+// server says it should have produced. The default, DefaultBookHash, is this
+// package's best-effort guess at a digest scheme and is NOT confirmed to
+// match however Polymarket's servers compute the Hash they attach to
+// price_change entries; pass a BookManagerArgs.HashFunc that replicates the
+// real algorithm once it's known, otherwise every legitimate delta may be
+// misdetected as drift.
+type BookHashFunc func(market string, bids, asks map[string]string) string
+
+// BookManagerArgs configures a BookManager.
+type BookManagerArgs struct {
+	// AssetIDs is the set of CLOB token/asset IDs to build and maintain books for.
+	AssetIDs []string
+
+	// NotifyBufferSize sets the buffer size of each asset's notification channel.
+	// Defaults to DefaultBookNotifyBufferSize. A full channel drops the oldest
+	// pending notification rather than blocking message processing.
+	NotifyBufferSize int
+
+	// OnMessage, if set, is invoked with every message after BookManager has
+	// applied it, so a caller can both maintain books and handle the raw stream.
+	OnMessage func(client *RealTimeDataClient, message Message)
+
+	// HashFunc overrides the continuity digest each book uses to check
+	// whether applying a price_change reproduced the state the server
+	// expects. Defaults to DefaultBookHash, which is an unverified guess at
+	// the real algorithm -- see BookHashFunc's doc comment. Set this to the
+	// confirmed server-side algorithm once it's known, or resnapshot storms
+	// are likely on every legitimate delta.
+	HashFunc BookHashFunc
+}
+
+// BookManager subscribes to the clob_market topic for a fixed set of asset IDs
+// and maintains a live, thread-safe L2 order book for each one by seeding from
+// agg_orderbook snapshots and applying price_change deltas, tick_size_change
+// resets, and market_resolved teardowns.
+//
+// Continuity across deltas is checked two ways: recordTimestamp catches a
+// price_change arriving out of order, and applying a delta recomputes a
+// digest of the book's own bids/asks (using BookManagerArgs.HashFunc, or
+// DefaultBookHash if unset) and compares it against the Hash the server
+// attached to that price_change, catching a price_change dropped in transit
+// (which, being otherwise in order, recordTimestamp alone would miss).
+// Either mismatch triggers a resnapshot.
+//
+// realtime/orderbook.Manager maintains the same books from the same stream,
+// using decimal.Decimal levels instead of this type's string/map-typed Book
+// so callers doing price arithmetic (MidPrice, Spread) don't have to parse
+// strings themselves. The two share the gap-detection design and the
+// IsBidSide helper rather than letting that logic drift apart; pick
+// BookManager for simple string-typed consumption and orderbook.Manager when
+// you need decimal precision.
+//
+// Assign BookManager.OnMessage as a RealTimeDataClient's OnMessage callback, and
+// call Subscribe once the client has connected.
+type BookManager struct {
+	args BookManagerArgs
+
+	mu    sync.RWMutex
+	books map[string]*bookState
+}
+
+type bookState struct {
+	mu sync.RWMutex
+
+	market       string
+	tickSize     string
+	minOrderSize string
+	negRisk      bool
+	bids         map[string]string // price -> size
+	asks         map[string]string // price -> size
+	hashFunc     BookHashFunc
+
+	seq           uint64
+	lastTimestamp int64 // last-seen message timestamp for this market, for gap detection
+	seeded        bool
+
+	notify chan BookUpdate
+}
+
+// NewBookManager creates a BookManager tracking the given asset IDs.
+func NewBookManager(args BookManagerArgs) *BookManager {
+	bufSize := args.NotifyBufferSize
+	if bufSize <= 0 {
+		bufSize = DefaultBookNotifyBufferSize
+	}
+
+	books := make(map[string]*bookState, len(args.AssetIDs))
+	for _, assetID := range args.AssetIDs {
+		books[assetID] = &bookState{
+			bids:     make(map[string]string),
+			asks:     make(map[string]string),
+			hashFunc: args.HashFunc,
+			notify:   make(chan BookUpdate, bufSize),
+		}
+	}
+
+	return &BookManager{args: args, books: books}
+}
+
+// Subscribe sends the clob_market subscription covering every tracked asset ID.
+// Call it from a RealTimeDataClientArgs.OnConnect callback so books are (re)seeded
+// on every connect, including reconnects.
+func (m *BookManager) Subscribe(ctx context.Context, client *RealTimeDataClient) error {
+	filters, err := json.Marshal(m.assetIDs())
+	if err != nil {
+		return fmt.Errorf("failed to build clob_market filters: %w", err)
+	}
+
+	return client.Subscribe(ctx, SubscriptionMessage{
+		Subscriptions: []Subscription{
+			{Topic: "clob_market", Type: "*", Filters: string(filters)},
+		},
+	})
+}
+
+func (m *BookManager) assetIDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.books))
+	for id := range m.books {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// OnMessage applies a clob_market message to the relevant book(s). Assign it as
+// a RealTimeDataClientArgs.OnMessage callback.
+func (m *BookManager) OnMessage(client *RealTimeDataClient, message Message) {
+	if message.Topic == "clob_market" {
+		payloadBytes, err := json.Marshal(message.Payload)
+		if err != nil {
+			log.Printf("book_manager: failed to re-marshal payload: %v", err)
+		} else {
+			switch message.Type {
+			case "agg_orderbook":
+				m.applyOrderBookEntries(payloadBytes)
+			case "price_change":
+				m.applyPriceChange(client, payloadBytes)
+			case "tick_size_change":
+				m.applyTickSizeChange(client, payloadBytes)
+			case "market_resolved":
+				m.applyMarketResolved(payloadBytes)
+			}
+		}
+	}
+
+	if m.args.OnMessage != nil {
+		m.args.OnMessage(client, message)
+	}
+}
+
+func (m *BookManager) applyOrderBookEntries(payloadBytes []byte) {
+	var entries []OrderBookEntry
+	if err := json.Unmarshal(payloadBytes, &entries); err != nil || len(entries) == 0 {
+		var entry OrderBookEntry
+		if err := json.Unmarshal(payloadBytes, &entry); err != nil {
+			return
+		}
+		entries = []OrderBookEntry{entry}
+	}
+
+	for _, entry := range entries {
+		state := m.stateFor(entry.AssetID)
+		if state == nil {
+			continue
+		}
+		m.seed(state, entry)
+	}
+}
+
+func (m *BookManager) seed(state *bookState, entry OrderBookEntry) {
+	bids := make(map[string]string, len(entry.Bids))
+	for _, lvl := range entry.Bids {
+		bids[lvl.Price] = lvl.Size
+	}
+	asks := make(map[string]string, len(entry.Asks))
+	for _, lvl := range entry.Asks {
+		asks[lvl.Price] = lvl.Size
+	}
+
+	state.mu.Lock()
+	state.market = entry.Market
+	state.tickSize = entry.TickSize
+	state.minOrderSize = entry.MinOrderSize
+	state.negRisk = entry.NegRisk
+	state.lastTimestamp = 0 // a fresh snapshot may restart the server's timestamp sequence
+	state.bids = bids
+	state.asks = asks
+	state.seeded = true
+	state.seq++
+	seq := state.seq
+	state.mu.Unlock()
+
+	m.publish(entry.AssetID, state, seq)
+}
+
+func (m *BookManager) applyPriceChange(client *RealTimeDataClient, payloadBytes []byte) {
+	var payload PriceChangePayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	ts, _ := strconv.ParseInt(payload.Timestamp, 10, 64)
+
+	for _, pc := range payload.PriceChanges {
+		state := m.stateFor(pc.AssetID)
+		if state == nil {
+			continue
+		}
+
+		if m.detectGap(state, ts) {
+			log.Printf("book_manager: detected out-of-order price_change for asset %s, resnapshotting", pc.AssetID)
+			m.resnapshot(client, pc.AssetID)
+			continue
+		}
+
+		state.mu.Lock()
+		side := state.asks
+		if IsBidSide(pc.Side) {
+			side = state.bids
+		}
+		if isZero(pc.Size) {
+			delete(side, pc.Price)
+		} else {
+			side[pc.Price] = pc.Size
+		}
+		state.seq++
+		seq := state.seq
+		newHash := state.hashLocked()
+		state.mu.Unlock()
+
+		m.publish(pc.AssetID, state, seq)
+
+		if pc.Hash != "" && newHash != pc.Hash {
+			log.Printf("book_manager: local book hash mismatch for asset %s after applying delta (likely a dropped price_change), resnapshotting", pc.AssetID)
+			m.resnapshot(client, pc.AssetID)
+		}
+	}
+}
+
+func (m *BookManager) applyTickSizeChange(client *RealTimeDataClient, payloadBytes []byte) {
+	var payload TickSizeChangePayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	for _, assetID := range payload.AssetID {
+		state := m.stateFor(assetID)
+		if state == nil {
+			continue
+		}
+		state.mu.Lock()
+		state.tickSize = payload.NewTickSize
+		state.mu.Unlock()
+
+		log.Printf("book_manager: tick size changed for asset %s (%s -> %s), resnapshotting", assetID, payload.OldTickSize, payload.NewTickSize)
+		m.resnapshot(client, assetID)
+	}
+}
+
+func (m *BookManager) applyMarketResolved(payloadBytes []byte) {
+	var payload ClobMarketPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, assetID := range payload.AssetIDs {
+		if state, ok := m.books[assetID]; ok {
+			close(state.notify)
+			delete(m.books, assetID)
+		}
+	}
+}
+
+// recordTimestamp reports whether ts looks out of order relative to the last
+// timestamp seen for state's market, and records ts as the new high-water mark either way.
+//
+// This only catches reordering: a price_change that arrives with an earlier
+// timestamp than one already applied. A price_change that is dropped in
+// transit but otherwise arrives in order passes this check undetected --
+// that's what applyPriceChange's post-apply contentHash comparison is for.
+func (state *bookState) recordTimestamp(ts int64) bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	gap := DetectTimestampGap(ts, state.lastTimestamp)
+	if ts > state.lastTimestamp {
+		state.lastTimestamp = ts
+	}
+	return gap
+}
+
+// DetectTimestampGap reports whether ts looks out of order relative to
+// lastTimestamp, the high-water mark of timestamps already applied. It holds
+// no state itself so BookManager's bookState and orderbook.Book -- which
+// track their own high-water marks under their own locks -- can share this
+// one rule instead of each maintaining their own copy of it.
+func DetectTimestampGap(ts, lastTimestamp int64) bool {
+	return ts != 0 && ts < lastTimestamp
+}
+
+func (m *BookManager) detectGap(state *bookState, ts int64) bool {
+	if !state.seeded {
+		return false
+	}
+	return state.recordTimestamp(ts)
+}
+
+// hashLocked digests state's current bids/asks via hashFunc (or
+// DefaultBookHash if unset), so applyPriceChange can detect a dropped delta:
+// unlike recordTimestamp's reordering check, a dropped-but-in-order message
+// leaves the local book out of sync with the server's, which shows up here
+// as a hash mismatch. Callers must hold state.mu.
+func (state *bookState) hashLocked() string {
+	hf := state.hashFunc
+	if hf == nil {
+		hf = DefaultBookHash
+	}
+	return hf(state.market, state.bids, state.asks)
+}
+
+// DefaultBookHash is the BookHashFunc used when BookManagerArgs.HashFunc
+// isn't set. It sha1-digests the market ID followed by each bid then ask
+// level as "price:size", sorted by price. This is this package's
+// best-effort guess at a continuity digest, not a confirmed reproduction of
+// Polymarket's server-side hash algorithm -- see BookHashFunc.
+func DefaultBookHash(market string, bids, asks map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(market)
+	for _, lvl := range sortedLevels(bids, true) {
+		sb.WriteString("|b:")
+		sb.WriteString(lvl.Price)
+		sb.WriteByte(':')
+		sb.WriteString(lvl.Size)
+	}
+	for _, lvl := range sortedLevels(asks, false) {
+		sb.WriteString("|a:")
+		sb.WriteString(lvl.Price)
+		sb.WriteByte(':')
+		sb.WriteString(lvl.Size)
+	}
+	sum := sha1.Sum([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// resnapshot forces the server to resend a fresh agg_orderbook for assetID by
+// briefly unsubscribing and resubscribing. It runs asynchronously so a single
+// reset doesn't stall processing of other assets' messages.
+func (m *BookManager) resnapshot(client *RealTimeDataClient, assetID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		filters, err := json.Marshal([]string{assetID})
+		if err != nil {
+			log.Printf("book_manager: failed to build resnapshot filter for %s: %v", assetID, err)
+			return
+		}
+		sub := SubscriptionMessage{
+			Subscriptions: []Subscription{{Topic: "clob_market", Type: "*", Filters: string(filters)}},
+		}
+
+		if err := client.Unsubscribe(ctx, sub); err != nil {
+			log.Printf("book_manager: resnapshot unsubscribe failed for %s: %v", assetID, err)
+		}
+		if err := client.Subscribe(ctx, sub); err != nil {
+			log.Printf("book_manager: resnapshot subscribe failed for %s: %v", assetID, err)
+		}
+	}()
+}
+
+func (m *BookManager) stateFor(assetID string) *bookState {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.books[assetID]
+}
+
+func (m *BookManager) publish(assetID string, state *bookState, seq uint64) {
+	select {
+	case state.notify <- BookUpdate{AssetID: assetID, Seq: seq}:
+	default:
+		// Drop the notification rather than block message processing; Snapshot
+		// always reflects the latest state regardless.
+	}
+}
+
+// Snapshot returns a sorted copy of the current book for assetID, along with its
+// change sequence number. ok is false if assetID isn't tracked or hasn't been seeded yet.
+func (m *BookManager) Snapshot(assetID string) (Book, uint64, bool) {
+	state := m.stateFor(assetID)
+	if state == nil {
+		return Book{}, 0, false
+	}
+
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	if !state.seeded {
+		return Book{}, 0, false
+	}
+
+	return Book{
+		Market:       state.market,
+		AssetID:      assetID,
+		TickSize:     state.tickSize,
+		MinOrderSize: state.minOrderSize,
+		NegRisk:      state.negRisk,
+		Bids:         sortedLevels(state.bids, true),
+		Asks:         sortedLevels(state.asks, false),
+	}, state.seq, true
+}
+
+// BestBid returns the highest-priced bid level for assetID.
+func (m *BookManager) BestBid(assetID string) (OrderBookLevel, bool) {
+	book, _, ok := m.Snapshot(assetID)
+	if !ok || len(book.Bids) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return book.Bids[0], true
+}
+
+// BestAsk returns the lowest-priced ask level for assetID.
+func (m *BookManager) BestAsk(assetID string) (OrderBookLevel, bool) {
+	book, _, ok := m.Snapshot(assetID)
+	if !ok || len(book.Asks) == 0 {
+		return OrderBookLevel{}, false
+	}
+	return book.Asks[0], true
+}
+
+// MidPrice returns the midpoint between the best bid and best ask for assetID.
+func (m *BookManager) MidPrice(assetID string) (float64, bool) {
+	bid, ok := m.BestBid(assetID)
+	if !ok {
+		return 0, false
+	}
+	ask, ok := m.BestAsk(assetID)
+	if !ok {
+		return 0, false
+	}
+
+	bidPrice, err1 := strconv.ParseFloat(bid.Price, 64)
+	askPrice, err2 := strconv.ParseFloat(ask.Price, 64)
+	if err1 != nil || err2 != nil {
+		return 0, false
+	}
+	return (bidPrice + askPrice) / 2, true
+}
+
+// SpreadBps returns the best bid/ask spread in basis points of the mid price for assetID.
+func (m *BookManager) SpreadBps(assetID string) (float64, bool) {
+	bid, ok := m.BestBid(assetID)
+	if !ok {
+		return 0, false
+	}
+	ask, ok := m.BestAsk(assetID)
+	if !ok {
+		return 0, false
+	}
+
+	bidPrice, err1 := strconv.ParseFloat(bid.Price, 64)
+	askPrice, err2 := strconv.ParseFloat(ask.Price, 64)
+	if err1 != nil || err2 != nil || bidPrice+askPrice == 0 {
+		return 0, false
+	}
+
+	mid := (bidPrice + askPrice) / 2
+	return (askPrice - bidPrice) / mid * 10000, true
+}
+
+// Notifications returns the channel that receives a BookUpdate every time
+// assetID's book changes. The channel is closed once the market resolves.
+func (m *BookManager) Notifications(assetID string) (<-chan BookUpdate, bool) {
+	state := m.stateFor(assetID)
+	if state == nil {
+		return nil, false
+	}
+	return state.notify, true
+}
+
+func sortedLevels(levels map[string]string, descending bool) []OrderBookLevel {
+	out := make([]OrderBookLevel, 0, len(levels))
+	for price, size := range levels {
+		out = append(out, OrderBookLevel{Price: price, Size: size})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		pi, _ := strconv.ParseFloat(out[i].Price, 64)
+		pj, _ := strconv.ParseFloat(out[j].Price, 64)
+		if descending {
+			return pi > pj
+		}
+		return pi < pj
+	})
+	return out
+}
+
+// IsBidSide reports whether a price_change entry's Side field denotes the bid
+// side. It's shared with realtime/orderbook, whose Manager applies the same
+// price_change deltas against a decimal-typed Book.
+func IsBidSide(side string) bool {
+	switch side {
+	case "BUY", "buy", "b", "B":
+		return true
+	default:
+		return false
+	}
+}
+
+func isZero(size string) bool {
+	v, err := strconv.ParseFloat(size, 64)
+	return err == nil && v == 0
+}
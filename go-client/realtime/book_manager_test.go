@@ -0,0 +1,89 @@
+package realtime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func toPayload(t *testing.T, v interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	return raw
+}
+
+// TestBookManagerSeedApplyResolve exercises a BookManager through a full
+// lifecycle: seeding a book from an agg_orderbook snapshot, applying a
+// price_change delta, and tearing the book down on market_resolved.
+func TestBookManagerSeedApplyResolve(t *testing.T) {
+	mgr := NewBookManager(BookManagerArgs{AssetIDs: []string{"asset-1"}})
+
+	if _, _, ok := mgr.Snapshot("asset-1"); ok {
+		t.Fatal("expected no snapshot before seeding")
+	}
+
+	seed := OrderBookEntry{
+		Market:   "0xmarket",
+		AssetID:  "asset-1",
+		TickSize: "0.01",
+		Hash:     "seed-hash",
+		Bids:     []OrderBookLevel{{Price: "0.50", Size: "10"}},
+		Asks:     []OrderBookLevel{{Price: "0.55", Size: "10"}},
+	}
+	mgr.OnMessage(nil, Message{Topic: "clob_market", Type: "agg_orderbook", Payload: toPayload(t, seed)})
+
+	book, seq, ok := mgr.Snapshot("asset-1")
+	if !ok {
+		t.Fatal("expected a snapshot after seeding")
+	}
+	if seq != 1 || len(book.Bids) != 1 || book.Bids[0].Price != "0.50" {
+		t.Fatalf("unexpected seeded book: seq=%d book=%+v", seq, book)
+	}
+
+	pc := PriceChangePayload{
+		Market:    "0xmarket",
+		Timestamp: "100",
+		PriceChanges: []PriceChange{
+			{AssetID: "asset-1", Price: "0.60", Side: "SELL", Size: "5"},
+		},
+	}
+	mgr.OnMessage(nil, Message{Topic: "clob_market", Type: "price_change", Payload: toPayload(t, pc)})
+
+	book, seq, ok = mgr.Snapshot("asset-1")
+	if !ok {
+		t.Fatal("expected a snapshot after applying a delta")
+	}
+	if seq != 2 || len(book.Asks) != 2 {
+		t.Fatalf("expected the delta to add a second ask level, got seq=%d book=%+v", seq, book)
+	}
+
+	resolved := ClobMarketPayload{AssetIDs: []string{"asset-1"}}
+	notifications, ok := mgr.Notifications("asset-1")
+	if !ok {
+		t.Fatal("expected a notification channel before resolution")
+	}
+	mgr.OnMessage(nil, Message{Topic: "clob_market", Type: "market_resolved", Payload: toPayload(t, resolved)})
+
+	if _, _, ok := mgr.Snapshot("asset-1"); ok {
+		t.Fatal("expected no snapshot after market_resolved")
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, open := <-notifications:
+			if !open {
+				return
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for the notification channel to close")
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package realtime
+
+import "encoding/json"
+
+// typedCallbacks holds the optional per-payload-type handlers registered via
+// RealTimeDataClient's On* methods. Guarded by RealTimeDataClient.callbacksMu.
+type typedCallbacks struct {
+	onPriceChange    func(PriceChangePayload)
+	onOrderBook      func(OrderBookEntry)
+	onLastTradePrice func(LastTradePricePayload)
+	onTickSizeChange func(TickSizeChangePayload)
+	onMarketCreated  func(ClobMarketPayload)
+	onMarketResolved func(ClobMarketPayload)
+}
+
+// OnPriceChange registers a callback for clob_market price_change messages.
+func (c *RealTimeDataClient) OnPriceChange(fn func(PriceChangePayload)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onPriceChange = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// OnOrderBook registers a callback for clob_market agg_orderbook messages. It is
+// invoked once per entry, since the server may batch several into one payload.
+func (c *RealTimeDataClient) OnOrderBook(fn func(OrderBookEntry)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onOrderBook = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// OnLastTradePrice registers a callback for clob_market last_trade_price messages.
+func (c *RealTimeDataClient) OnLastTradePrice(fn func(LastTradePricePayload)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onLastTradePrice = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// OnTickSizeChange registers a callback for clob_market tick_size_change messages.
+func (c *RealTimeDataClient) OnTickSizeChange(fn func(TickSizeChangePayload)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onTickSizeChange = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// OnMarketCreated registers a callback for clob_market market_created messages.
+func (c *RealTimeDataClient) OnMarketCreated(fn func(ClobMarketPayload)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onMarketCreated = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// OnMarketResolved registers a callback for clob_market market_resolved messages.
+func (c *RealTimeDataClient) OnMarketResolved(fn func(ClobMarketPayload)) *RealTimeDataClient {
+	c.callbacksMu.Lock()
+	c.callbacks.onMarketResolved = fn
+	c.callbacksMu.Unlock()
+	return c
+}
+
+// dispatchTyped decodes msg's payload into the concrete type matching its
+// topic/type and invokes the matching registered On* callback, if any.
+func (c *RealTimeDataClient) dispatchTyped(msg Message) {
+	if msg.Topic != "clob_market" {
+		return
+	}
+
+	c.callbacksMu.RLock()
+	cbs := c.callbacks
+	c.callbacksMu.RUnlock()
+
+	switch msg.Type {
+	case "price_change":
+		if cbs.onPriceChange == nil {
+			return
+		}
+		var payload PriceChangePayload
+		if decodePayload(msg.Payload, &payload) {
+			cbs.onPriceChange(payload)
+		}
+
+	case "agg_orderbook":
+		if cbs.onOrderBook == nil {
+			return
+		}
+		var entries []OrderBookEntry
+		if decodePayload(msg.Payload, &entries) {
+			for _, entry := range entries {
+				cbs.onOrderBook(entry)
+			}
+			return
+		}
+		var entry OrderBookEntry
+		if decodePayload(msg.Payload, &entry) {
+			cbs.onOrderBook(entry)
+		}
+
+	case "last_trade_price":
+		if cbs.onLastTradePrice == nil {
+			return
+		}
+		var payload LastTradePricePayload
+		if decodePayload(msg.Payload, &payload) {
+			cbs.onLastTradePrice(payload)
+		}
+
+	case "tick_size_change":
+		if cbs.onTickSizeChange == nil {
+			return
+		}
+		var payload TickSizeChangePayload
+		if decodePayload(msg.Payload, &payload) {
+			cbs.onTickSizeChange(payload)
+		}
+
+	case "market_created":
+		if cbs.onMarketCreated == nil {
+			return
+		}
+		var payload ClobMarketPayload
+		if decodePayload(msg.Payload, &payload) {
+			cbs.onMarketCreated(payload)
+		}
+
+	case "market_resolved":
+		if cbs.onMarketResolved == nil {
+			return
+		}
+		var payload ClobMarketPayload
+		if decodePayload(msg.Payload, &payload) {
+			cbs.onMarketResolved(payload)
+		}
+	}
+}
+
+func decodePayload(payload interface{}, out interface{}) bool {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, out) == nil
+}
@@ -1,9 +1,18 @@
 package realtime
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"math"
+	"math/rand"
+	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -12,6 +21,31 @@ import (
 const (
 	DefaultHost         = "wss://ws-live-data.polymarket.com"
 	DefaultPingInterval = 5 * time.Second
+
+	// DefaultInitialBackoff is the reconnect delay used after the first failed dial.
+	DefaultInitialBackoff = 500 * time.Millisecond
+
+	// DefaultMaxBackoff caps how long connectLoop will ever wait between dial attempts.
+	DefaultMaxBackoff = 30 * time.Second
+
+	// DefaultBackoffMultiplier is the exponential growth factor applied per failed attempt.
+	DefaultBackoffMultiplier = 2.0
+
+	// DefaultJitterFraction of 1 means full-jitter (AWS-style): sleep is drawn
+	// uniformly from [0, cap). A fraction of 0 disables jitter entirely.
+	DefaultJitterFraction = 1.0
+
+	// DefaultStabilityWindow is how long a connection must stay up before the
+	// reconnect attempt counter is reset back to zero.
+	DefaultStabilityWindow = 30 * time.Second
+
+	// DefaultInboundQueueSize bounds the number of unprocessed frames buffered
+	// between the socket read loop and message dispatch.
+	DefaultInboundQueueSize = 256
+
+	// DefaultStatusChannelSize bounds the number of buffered, unread statuses
+	// on the channel returned by StatusCh.
+	DefaultStatusChannelSize = 16
 )
 
 // RealTimeDataClientArgs Interface representing the arguments for initializing a RealTimeDataClient.
@@ -34,23 +68,69 @@ type RealTimeDataClientArgs struct {
 	// Optional flag to enable or disable automatic reconnection when the connection is lost.
 	// Defaults to true.
 	AutoReconnect *bool
+
+	// InitialBackoff is the delay before the first reconnect attempt. Defaults to DefaultInitialBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the exponential backoff delay. Defaults to DefaultMaxBackoff.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff delay after each failed attempt. Defaults to DefaultBackoffMultiplier.
+	Multiplier float64
+
+	// JitterFraction controls how much of the backoff delay is randomized, in [0, 1].
+	// 1 (the default) is full-jitter: the sleep is drawn uniformly from [0, cap).
+	// 0 disables jitter, always sleeping for the full cap.
+	JitterFraction float64
+
+	// MaxReconnectAttempts bounds how many consecutive failed attempts are tolerated
+	// before the client gives up and transitions to ConnectionStatusFailed.
+	// Zero (the default) means unlimited attempts.
+	MaxReconnectAttempts int
+
+	// StabilityWindow is how long a connection must stay healthy before the
+	// reconnect attempt counter resets to zero. Defaults to DefaultStabilityWindow.
+	StabilityWindow time.Duration
+
+	// InboundQueueSize bounds how many received frames may be buffered ahead of
+	// dispatch. Once full, the socket read loop blocks, applying backpressure
+	// instead of buffering unboundedly. Defaults to DefaultInboundQueueSize.
+	InboundQueueSize int
 }
 
 // RealTimeDataClient A client for managing real-time WebSocket connections.
 type RealTimeDataClient struct {
 	host          string
 	pingInterval  time.Duration
-	autoReconnect bool
+	autoReconnect atomic.Bool
+
+	initialBackoff       time.Duration
+	maxBackoff           time.Duration
+	multiplier           float64
+	jitterFraction       float64
+	maxReconnectAttempts int
+	stabilityWindow      time.Duration
 
 	onConnect       func(client *RealTimeDataClient)
 	onCustomMessage func(client *RealTimeDataClient, message Message)
 	onStatusChange  func(status ConnectionStatus)
+	statusCh        chan ConnectionStatus
 
-	conn *websocket.Conn
-	mu   sync.Mutex // Protects conn and writing to it
+	callbacksMu sync.RWMutex
+	callbacks   typedCallbacks
 
-	stopChan chan struct{}
-	wg       sync.WaitGroup
+	conn    *websocket.Conn
+	readyCh chan struct{} // closed when conn becomes non-nil; replaced on disconnect
+	mu      sync.Mutex    // protects conn, readyCh, and subscriptions
+	writeMu sync.Mutex    // serializes WriteMessage calls; gorilla/websocket allows only one writer at a time
+
+	subscriptions map[string]Subscription // cache of active subscriptions, replayed on reconnect
+
+	inbox chan []byte // bounded queue between the socket read loop and dispatch
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
 }
 
 // NewRealTimeDataClient Constructs a new RealTimeDataClient instance.
@@ -70,15 +150,58 @@ func NewRealTimeDataClient(args RealTimeDataClientArgs) *RealTimeDataClient {
 		autoReconnect = *args.AutoReconnect
 	}
 
-	return &RealTimeDataClient{
-		host:            host,
-		pingInterval:    pingInterval,
-		autoReconnect:   autoReconnect,
-		onConnect:       args.OnConnect,
-		onCustomMessage: args.OnMessage,
-		onStatusChange:  args.OnStatusChange,
-		stopChan:        make(chan struct{}),
+	initialBackoff := args.InitialBackoff
+	if initialBackoff == 0 {
+		initialBackoff = DefaultInitialBackoff
 	}
+
+	maxBackoff := args.MaxBackoff
+	if maxBackoff == 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	multiplier := args.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultBackoffMultiplier
+	}
+
+	jitterFraction := args.JitterFraction
+	if jitterFraction == 0 {
+		jitterFraction = DefaultJitterFraction
+	}
+
+	stabilityWindow := args.StabilityWindow
+	if stabilityWindow == 0 {
+		stabilityWindow = DefaultStabilityWindow
+	}
+
+	inboundQueueSize := args.InboundQueueSize
+	if inboundQueueSize <= 0 {
+		inboundQueueSize = DefaultInboundQueueSize
+	}
+
+	c := &RealTimeDataClient{
+		host:                 host,
+		pingInterval:         pingInterval,
+		initialBackoff:       initialBackoff,
+		maxBackoff:           maxBackoff,
+		multiplier:           multiplier,
+		jitterFraction:       jitterFraction,
+		maxReconnectAttempts: args.MaxReconnectAttempts,
+		stabilityWindow:      stabilityWindow,
+		onConnect:            args.OnConnect,
+		onCustomMessage:      args.OnMessage,
+		onStatusChange:       args.OnStatusChange,
+		statusCh:             make(chan ConnectionStatus, DefaultStatusChannelSize),
+		readyCh:              make(chan struct{}),
+		subscriptions:        make(map[string]Subscription),
+		inbox:                make(chan []byte, inboundQueueSize),
+		stopChan:             make(chan struct{}),
+	}
+	c.autoReconnect.Store(autoReconnect)
+
+	go c.dispatchLoop()
+	return c
 }
 
 // Connect Establishes a WebSocket connection to the server.
@@ -88,7 +211,54 @@ func (c *RealTimeDataClient) Connect() *RealTimeDataClient {
 	return c
 }
 
+// dial opens the WebSocket connection, aborting early if stopChan closes
+// while the dial is in flight. A plain ctx cancellation only interrupts the
+// TCP-connect phase: gorilla/websocket's DialContext only wires ctx into the
+// handshake's response read via SetDeadline, not via ctx.Done(), so once the
+// underlying socket is connected we have to force that read to unblock
+// ourselves by setting an immediate deadline on it.
+func (c *RealTimeDataClient) dial() (*websocket.Conn, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		netConn net.Conn
+	)
+	dialer := *websocket.DefaultDialer
+	dialer.NetDialContext = func(dialCtx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		netConn = conn
+		mu.Unlock()
+		return conn, nil
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-c.stopChan:
+			cancel()
+			mu.Lock()
+			conn := netConn
+			mu.Unlock()
+			if conn != nil {
+				conn.SetDeadline(time.Now())
+			}
+		case <-done:
+		}
+	}()
+
+	conn, _, err := dialer.DialContext(ctx, c.host, nil)
+	return conn, err
+}
+
 func (c *RealTimeDataClient) connectLoop() {
+	var attempt atomic.Int32
 	for {
 		select {
 		case <-c.stopChan:
@@ -96,50 +266,132 @@ func (c *RealTimeDataClient) connectLoop() {
 		default:
 		}
 
-		conn, _, err := websocket.DefaultDialer.Dial(c.host, nil)
+		conn, err := c.dial()
 		if err != nil {
+			select {
+			case <-c.stopChan:
+				// Disconnect/Run(ctx) canceled the in-flight dial; stop, don't
+				// treat it as a dial failure to retry.
+				return
+			default:
+			}
+
 			log.Printf("dial error: %v", err)
-			if c.autoReconnect {
-				time.Sleep(1 * time.Second) // Wait before reconnecting
-				continue
+			if !c.autoReconnect.Load() {
+				return
+			}
+
+			n := attempt.Add(1)
+			if c.maxReconnectAttempts > 0 && int(n) > c.maxReconnectAttempts {
+				c.notifyStatusChange(ConnectionStatusFailed)
+				return
+			}
+
+			if !c.sleepBackoff(int(n)) {
+				return
 			}
+			continue
+		}
+
+		// The dial raced a concurrent Disconnect/Run(ctx) cancellation and won;
+		// don't install the connection or start its goroutines, close it instead.
+		select {
+		case <-c.stopChan:
+			conn.Close()
 			return
+		default:
 		}
 
+		attempt.Store(0)
+
 		c.mu.Lock()
 		c.conn = conn
+		close(c.readyCh)
 		c.mu.Unlock()
 
 		c.notifyStatusChange(ConnectionStatusConnected)
+		c.resubscribeAll()
 		if c.onConnect != nil {
 			c.onConnect(c)
 		}
 
+		// Reset the attempt counter once the connection has proven stable, so a
+		// brief blip doesn't leave a long-lived connection starting from a large backoff.
+		stableTimer := time.AfterFunc(c.stabilityWindow, func() { attempt.Store(0) })
+
 		// Start ping loop
 		stopPing := make(chan struct{})
 		go c.pingLoop(stopPing)
 
-		// Read loop
+		// Read loop. Frames are handed to the bounded inbox rather than processed
+		// inline, so a slow consumer applies backpressure on this loop (and, in
+		// turn, on the TCP connection) instead of buffering without limit.
+	readLoop:
 		for {
-			_, message, err := c.conn.ReadMessage()
+			msgType, message, err := c.conn.ReadMessage()
 			if err != nil {
 				log.Printf("read error: %v", err)
 				break
 			}
-			c.handleMessage(message)
+
+			if msgType == websocket.BinaryMessage && isGzipFrame(message) {
+				decompressed, err := gzipDecompress(message)
+				if err != nil {
+					log.Printf("gzip decompress error: %v", err)
+					continue
+				}
+				message = decompressed
+			}
+
+			select {
+			case c.inbox <- message:
+			case <-c.stopChan:
+				break readLoop
+			}
 		}
 
+		stableTimer.Stop()
 		close(stopPing)
 		c.mu.Lock()
 		c.conn.Close()
 		c.conn = nil
+		c.readyCh = make(chan struct{})
 		c.mu.Unlock()
 		c.notifyStatusChange(ConnectionStatusDisconnected)
 
-		if !c.autoReconnect {
+		if !c.autoReconnect.Load() {
 			break
 		}
-		time.Sleep(1 * time.Second)
+
+		n := attempt.Add(1)
+		if c.maxReconnectAttempts > 0 && int(n) > c.maxReconnectAttempts {
+			c.notifyStatusChange(ConnectionStatusFailed)
+			return
+		}
+
+		if !c.sleepBackoff(int(n)) {
+			return
+		}
+	}
+}
+
+// sleepBackoff waits out a full-jitter exponential backoff delay for the given
+// attempt number, returning false if the client was stopped while waiting.
+func (c *RealTimeDataClient) sleepBackoff(attempt int) bool {
+	capDelay := float64(c.maxBackoff)
+	base := float64(c.initialBackoff) * math.Pow(c.multiplier, float64(attempt-1))
+	if base > capDelay {
+		base = capDelay
+	}
+
+	floor := base * (1 - c.jitterFraction)
+	delay := time.Duration(floor + rand.Float64()*(base-floor))
+
+	select {
+	case <-time.After(delay):
+		return true
+	case <-c.stopChan:
+		return false
 	}
 }
 
@@ -153,17 +405,50 @@ func (c *RealTimeDataClient) pingLoop(stop <-chan struct{}) {
 			return
 		case <-ticker.C:
 			c.mu.Lock()
-			if c.conn != nil {
-				err := c.conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+			conn := c.conn
+			c.mu.Unlock()
+			if conn != nil {
+				c.writeMu.Lock()
+				err := conn.WriteMessage(websocket.TextMessage, []byte("ping"))
+				c.writeMu.Unlock()
 				if err != nil {
 					log.Printf("ping error: %v", err)
 				}
 			}
-			c.mu.Unlock()
 		}
 	}
 }
 
+// dispatchLoop consumes frames from the inbox and processes them, decoupling
+// socket reads from (potentially slow) message handling.
+func (c *RealTimeDataClient) dispatchLoop() {
+	for {
+		select {
+		case data := <-c.inbox:
+			c.handleMessage(data)
+		case <-c.stopChan:
+			return
+		}
+	}
+}
+
+const gzipMagic0, gzipMagic1 = 0x1f, 0x8b
+
+// isGzipFrame reports whether data starts with the gzip magic bytes, mirroring
+// the gzip-frame detection used by exchange WS clients such as goex.
+func isGzipFrame(data []byte) bool {
+	return len(data) >= 2 && data[0] == gzipMagic0 && data[1] == gzipMagic1
+}
+
+func gzipDecompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
 func (c *RealTimeDataClient) handleMessage(data []byte) {
 	if len(data) == 0 {
 		return
@@ -174,6 +459,7 @@ func (c *RealTimeDataClient) handleMessage(data []byte) {
 	if err := json.Unmarshal(data, &msg); err == nil {
 		// Basic check if it looks like a valid message
 		if msg.Topic != "" || msg.Type != "" { // Or check payload != nil
+			c.dispatchTyped(msg)
 			if c.onCustomMessage != nil {
 				c.onCustomMessage(c, msg)
 			}
@@ -185,68 +471,156 @@ func (c *RealTimeDataClient) handleMessage(data []byte) {
 	}
 }
 
-// Disconnect Closes the WebSocket connection.
+// Disconnect closes the WebSocket connection and stops reconnecting. Safe to call more than once.
 func (c *RealTimeDataClient) Disconnect() {
-	c.autoReconnect = false
-	close(c.stopChan)
-	c.mu.Lock()
-	if c.conn != nil {
-		c.conn.Close()
-	}
-	c.mu.Unlock()
+	c.closeOnce.Do(func() {
+		c.autoReconnect.Store(false)
+		close(c.stopChan)
+		c.mu.Lock()
+		if c.conn != nil {
+			c.conn.Close()
+		}
+		c.mu.Unlock()
+	})
 }
 
-// Subscribe Subscribes to a data stream by sending a subscription message.
-func (c *RealTimeDataClient) Subscribe(msg SubscriptionMessage) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// Close is an alias for Disconnect, provided for symmetry with Run(ctx).
+func (c *RealTimeDataClient) Close() {
+	c.Disconnect()
+}
 
-	if c.conn == nil {
-		log.Println("Socket not open.")
-		return
+// Run connects and blocks until ctx is done or the client is otherwise stopped,
+// disconnecting before it returns. It is a context-aware alternative to calling
+// Connect and managing shutdown via Disconnect/Close directly.
+func (c *RealTimeDataClient) Run(ctx context.Context) error {
+	c.Connect()
+
+	select {
+	case <-ctx.Done():
+		c.Close()
+		return ctx.Err()
+	case <-c.stopChan:
+		return nil
 	}
+}
 
-	msg.Action = "subscribe"
-	data, err := json.Marshal(msg)
-	if err != nil {
-		log.Printf("subscribe marshal error: %v", err)
-		return
+// Subscribe subscribes to a data stream, caching it so it is automatically replayed
+// on every future reconnect. It blocks until the socket is ready to accept writes,
+// or until ctx is done, whichever comes first.
+func (c *RealTimeDataClient) Subscribe(ctx context.Context, msg SubscriptionMessage) error {
+	for _, sub := range msg.Subscriptions {
+		c.mu.Lock()
+		c.subscriptions[subscriptionKey(sub)] = sub
+		c.mu.Unlock()
 	}
 
-	err = c.conn.WriteMessage(websocket.TextMessage, data)
-	if err != nil {
-		log.Printf("subscribe error: %v", err)
-		c.conn.Close()
+	return c.send(ctx, "subscribe", msg)
+}
+
+// Unsubscribe unsubscribes from a data stream and drops it from the replay cache.
+// It blocks until the socket is ready to accept writes, or until ctx is done.
+func (c *RealTimeDataClient) Unsubscribe(ctx context.Context, msg SubscriptionMessage) error {
+	for _, sub := range msg.Subscriptions {
+		c.mu.Lock()
+		delete(c.subscriptions, subscriptionKey(sub))
+		c.mu.Unlock()
 	}
+
+	return c.send(ctx, "unsubscribe", msg)
 }
 
-// Unsubscribe Unsubscribes from a data stream by sending an unsubscription message.
-func (c *RealTimeDataClient) Unsubscribe(msg SubscriptionMessage) {
+// resubscribeAll replays every cached subscription after a (re)connect, so
+// consumers don't have to manually resubscribe when the socket drops.
+func (c *RealTimeDataClient) resubscribeAll() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.conn == nil {
-		log.Println("Socket not open.")
+	if len(c.subscriptions) == 0 {
+		c.mu.Unlock()
 		return
 	}
+	subs := make([]Subscription, 0, len(c.subscriptions))
+	for _, sub := range c.subscriptions {
+		subs = append(subs, sub)
+	}
+	c.mu.Unlock()
+
+	if err := c.send(context.Background(), "subscribe", SubscriptionMessage{Subscriptions: subs}); err != nil {
+		log.Printf("resubscribe error: %v", err)
+	}
+}
 
-	msg.Action = "unsubscribe"
+// send waits for the socket to be ready, then writes a subscription-style message
+// with the given action. It is shared by Subscribe, Unsubscribe, and resubscribeAll.
+func (c *RealTimeDataClient) send(ctx context.Context, action string, msg SubscriptionMessage) error {
+	if err := c.waitReady(ctx); err != nil {
+		return err
+	}
+
+	msg.Action = action
 	data, err := json.Marshal(msg)
 	if err != nil {
-		log.Printf("unsubscribe marshal error: %v", err)
-		return
+		return fmt.Errorf("%s marshal error: %w", action, err)
+	}
+
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		return fmt.Errorf("%s: socket not open", action)
 	}
 
-	log.Printf("unsubscribing: %v", msg)
-	err = c.conn.WriteMessage(websocket.TextMessage, data)
+	c.writeMu.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	c.writeMu.Unlock()
 	if err != nil {
-		log.Printf("unsubscribe error: %v", err)
-		c.conn.Close()
+		conn.Close()
+		return fmt.Errorf("%s error: %w", action, err)
+	}
+	return nil
+}
+
+// waitReady blocks until the socket is connected, ctx is done, or the client is stopped.
+func (c *RealTimeDataClient) waitReady(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if c.conn != nil {
+			c.mu.Unlock()
+			return nil
+		}
+		ready := c.readyCh
+		c.mu.Unlock()
+
+		select {
+		case <-ready:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.stopChan:
+			return fmt.Errorf("client stopped")
+		}
 	}
 }
 
+// subscriptionKey identifies a subscription for caching/replay purposes.
+func subscriptionKey(sub Subscription) string {
+	return sub.Topic + "|" + sub.Type + "|" + sub.Filters
+}
+
+// StatusCh returns a channel-based alternative to RealTimeDataClientArgs.OnStatusChange:
+// every connection status transition is also sent on it. The channel is
+// buffered (DefaultStatusChannelSize); a status is dropped, not blocked on,
+// if the buffer is full, so a slow or absent reader can't stall connectLoop.
+// It is never closed, including after Disconnect/Close, since another
+// goroutine may still be racing to send on it.
+func (c *RealTimeDataClient) StatusCh() <-chan ConnectionStatus {
+	return c.statusCh
+}
+
 func (c *RealTimeDataClient) notifyStatusChange(status ConnectionStatus) {
 	if c.onStatusChange != nil {
 		c.onStatusChange(status)
 	}
+	select {
+	case c.statusCh <- status:
+	default:
+	}
 }
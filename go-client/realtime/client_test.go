@@ -0,0 +1,173 @@
+package realtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newEchoServer starts an httptest WS server that, on each connection, writes
+// a single price_change frame and then echoes back whatever it receives.
+func newEchoServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		frame := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","p":"0.5","s":"BUY","si":"10"}],"t":"1"}}`
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+			return
+		}
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	return server, wsURL
+}
+
+func TestRealTimeDataClientDispatchesPriceChange(t *testing.T) {
+	server, wsURL := newEchoServer(t)
+	defer server.Close()
+
+	received := make(chan PriceChangePayload, 1)
+
+	client := NewRealTimeDataClient(RealTimeDataClientArgs{Host: wsURL})
+	client.OnPriceChange(func(payload PriceChangePayload) {
+		select {
+		case received <- payload:
+		default:
+		}
+	})
+	client.Connect()
+	defer client.Close()
+
+	select {
+	case payload := <-received:
+		if payload.Market != "0xabc" {
+			t.Fatalf("expected market 0xabc, got %q", payload.Market)
+		}
+		if len(payload.PriceChanges) != 1 || payload.PriceChanges[0].AssetID != "asset-1" {
+			t.Fatalf("unexpected price changes: %+v", payload.PriceChanges)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for price_change dispatch")
+	}
+}
+
+// TestRealTimeDataClientStatusCh verifies StatusCh surfaces the same
+// transitions as OnStatusChange, so callers can pick whichever fits their
+// control flow.
+func TestRealTimeDataClientStatusCh(t *testing.T) {
+	server, wsURL := newEchoServer(t)
+	defer server.Close()
+
+	client := NewRealTimeDataClient(RealTimeDataClientArgs{Host: wsURL})
+	statuses := client.StatusCh()
+	client.Connect()
+	defer client.Close()
+
+	seen := map[ConnectionStatus]bool{}
+	for !seen[ConnectionStatusConnecting] || !seen[ConnectionStatusConnected] {
+		select {
+		case status := <-statuses:
+			seen[status] = true
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for CONNECTING and CONNECTED on StatusCh, saw: %v", seen)
+		}
+	}
+}
+
+// TestRealTimeDataClientDisconnectDuringDialDoesNotLeakConnection verifies
+// that calling Disconnect while a dial is still in flight doesn't leave a
+// connection (and its ping/read goroutines) running afterward -- whether
+// because the dial is aborted outright or because it resolves and is then
+// torn back down. It mirrors the maintainer's own repro: Disconnect mid
+// handshake against a slow-to-upgrade server, then watch goroutine count.
+func TestRealTimeDataClientDisconnectDuringDialDoesNotLeakConnection(t *testing.T) {
+	baseline := settledGoroutineCount(t)
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Simulate a slow handshake so the client's dial is still in flight
+		// when Disconnect runs below.
+		time.Sleep(300 * time.Millisecond)
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	client := NewRealTimeDataClient(RealTimeDataClientArgs{Host: wsURL})
+	client.Connect()
+
+	time.Sleep(50 * time.Millisecond) // give the dial time to start
+	client.Disconnect()
+
+	// Give the in-flight dial (300ms handshake) plenty of room to resolve one
+	// way or the other, then the connection and its goroutines must be gone.
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		client.mu.Lock()
+		conn := client.conn
+		client.mu.Unlock()
+		if conn == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected no connection to remain installed after Disconnect raced an in-flight dial")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Close the test server itself (and thus its still-sleeping handler
+	// goroutine) before sampling goroutine counts, so its own teardown isn't
+	// mistaken for a leak in the client.
+	server.Close()
+
+	after := settledGoroutineCount(t)
+	if after > baseline {
+		t.Fatalf("goroutine count elevated after Disconnect raced an in-flight dial: baseline %d, now %d", baseline, after)
+	}
+}
+
+// settledGoroutineCount returns runtime.NumGoroutine after giving background
+// goroutines (GC, prior test cleanup) a moment to settle, retrying a few
+// times to avoid a flaky one-off sample.
+func settledGoroutineCount(t *testing.T) int {
+	t.Helper()
+	runtime.GC()
+	count := runtime.NumGoroutine()
+	for i := 0; i < 5; i++ {
+		time.Sleep(20 * time.Millisecond)
+		runtime.GC()
+		next := runtime.NumGoroutine()
+		if next <= count {
+			count = next
+		}
+	}
+	return count
+}
@@ -0,0 +1,170 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Filter is a built, topic-specific subscription filter produced by one of
+// the New*Filter builders. Pass it to Subscription.WithFilter instead of
+// hand-assembling Subscription.Topic/Filters JSON.
+type Filter struct {
+	Topic string
+	JSON  string
+}
+
+// WithFilter returns a copy of sub with its Topic and Filters set from f.
+func (sub Subscription) WithFilter(f Filter) Subscription {
+	sub.Topic = f.Topic
+	sub.Filters = f.JSON
+	return sub
+}
+
+func buildFilter(topic string, data map[string]interface{}) (Filter, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return Filter{}, fmt.Errorf("failed to build %s filter: %w", topic, err)
+	}
+	return Filter{Topic: topic, JSON: string(encoded)}, nil
+}
+
+// ClobMarketFilterBuilder builds a Filter for the clob_market topic, which
+// streams order book and price updates.
+type ClobMarketFilterBuilder struct {
+	markets  []string
+	assetIDs []string
+	events   []string
+}
+
+// NewClobMarketFilter starts building a clob_market Filter.
+func NewClobMarketFilter() *ClobMarketFilterBuilder {
+	return &ClobMarketFilterBuilder{}
+}
+
+// Markets restricts the filter to the given condition/market IDs.
+func (b *ClobMarketFilterBuilder) Markets(markets ...string) *ClobMarketFilterBuilder {
+	b.markets = append(b.markets, markets...)
+	return b
+}
+
+// AssetIDs restricts the filter to the given CLOB token/asset IDs.
+func (b *ClobMarketFilterBuilder) AssetIDs(assetIDs ...string) *ClobMarketFilterBuilder {
+	b.assetIDs = append(b.assetIDs, assetIDs...)
+	return b
+}
+
+// EventTypes restricts the filter to the given clob_market message types
+// (e.g. "price_change", "agg_orderbook", "tick_size_change").
+func (b *ClobMarketFilterBuilder) EventTypes(eventTypes ...string) *ClobMarketFilterBuilder {
+	b.events = append(b.events, eventTypes...)
+	return b
+}
+
+// Build validates and serializes the filter. Markets and AssetIDs are
+// mutually exclusive: agg_orderbook/price_change are keyed by asset ID on the
+// wire, so filtering by market ID as well as asset ID is ambiguous and
+// rejected here rather than silently preferring one.
+func (b *ClobMarketFilterBuilder) Build() (Filter, error) {
+	if len(b.markets) > 0 && len(b.assetIDs) > 0 {
+		return Filter{}, fmt.Errorf("clob_market filter: markets and asset IDs are mutually exclusive")
+	}
+
+	data := map[string]interface{}{}
+	if len(b.markets) > 0 {
+		data["markets"] = b.markets
+	}
+	if len(b.assetIDs) > 0 {
+		data["asset_ids"] = b.assetIDs
+	}
+	if len(b.events) > 0 {
+		data["event_types"] = b.events
+	}
+
+	return buildFilter("clob_market", data)
+}
+
+// ClobUserFilterBuilder builds a Filter for the clob_user topic, which
+// streams a single authenticated user's orders and trades.
+type ClobUserFilterBuilder struct {
+	markets []string
+	events  []string
+}
+
+// NewClobUserFilter starts building a clob_user Filter.
+func NewClobUserFilter() *ClobUserFilterBuilder {
+	return &ClobUserFilterBuilder{}
+}
+
+// Markets restricts the filter to the given condition/market IDs.
+func (b *ClobUserFilterBuilder) Markets(markets ...string) *ClobUserFilterBuilder {
+	b.markets = append(b.markets, markets...)
+	return b
+}
+
+// EventTypes restricts the filter to the given clob_user message types (e.g. "order", "trade").
+func (b *ClobUserFilterBuilder) EventTypes(eventTypes ...string) *ClobUserFilterBuilder {
+	b.events = append(b.events, eventTypes...)
+	return b
+}
+
+// Build serializes the filter. clob_user has no mutually exclusive options,
+// unlike clob_market, so Build cannot fail; it still returns an error to keep
+// the same signature as the other builders.
+func (b *ClobUserFilterBuilder) Build() (Filter, error) {
+	data := map[string]interface{}{}
+	if len(b.markets) > 0 {
+		data["markets"] = b.markets
+	}
+	if len(b.events) > 0 {
+		data["event_types"] = b.events
+	}
+
+	return buildFilter("clob_user", data)
+}
+
+// ActivityFilterBuilder builds a Filter for the activity topic, which streams
+// on-chain trade/position activity.
+type ActivityFilterBuilder struct {
+	markets []string
+	users   []string
+	events  []string
+}
+
+// NewActivityFilter starts building an activity Filter.
+func NewActivityFilter() *ActivityFilterBuilder {
+	return &ActivityFilterBuilder{}
+}
+
+// Markets restricts the filter to the given condition/market IDs.
+func (b *ActivityFilterBuilder) Markets(markets ...string) *ActivityFilterBuilder {
+	b.markets = append(b.markets, markets...)
+	return b
+}
+
+// Users restricts the filter to the given user wallet addresses.
+func (b *ActivityFilterBuilder) Users(users ...string) *ActivityFilterBuilder {
+	b.users = append(b.users, users...)
+	return b
+}
+
+// EventTypes restricts the filter to the given activity message types (e.g. "trade", "split", "merge").
+func (b *ActivityFilterBuilder) EventTypes(eventTypes ...string) *ActivityFilterBuilder {
+	b.events = append(b.events, eventTypes...)
+	return b
+}
+
+// Build serializes the filter.
+func (b *ActivityFilterBuilder) Build() (Filter, error) {
+	data := map[string]interface{}{}
+	if len(b.markets) > 0 {
+		data["markets"] = b.markets
+	}
+	if len(b.users) > 0 {
+		data["users"] = b.users
+	}
+	if len(b.events) > 0 {
+		data["event_types"] = b.events
+	}
+
+	return buildFilter("activity", data)
+}
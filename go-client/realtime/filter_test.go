@@ -0,0 +1,43 @@
+package realtime
+
+import "testing"
+
+func TestClobMarketFilterBuild(t *testing.T) {
+	filter, err := NewClobMarketFilter().AssetIDs("asset-1", "asset-2").EventTypes("price_change").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if filter.Topic != "clob_market" {
+		t.Fatalf("unexpected topic: %s", filter.Topic)
+	}
+
+	const want = `{"asset_ids":["asset-1","asset-2"],"event_types":["price_change"]}`
+	if filter.JSON != want {
+		t.Fatalf("unexpected JSON: got %s, want %s", filter.JSON, want)
+	}
+}
+
+func TestClobMarketFilterRejectsMutuallyExclusiveOptions(t *testing.T) {
+	_, err := NewClobMarketFilter().Markets("0xabc").AssetIDs("asset-1").Build()
+	if err == nil {
+		t.Fatal("expected an error when combining Markets and AssetIDs")
+	}
+}
+
+func TestSubscriptionWithFilter(t *testing.T) {
+	filter, err := NewActivityFilter().Users("0xabc").EventTypes("trade").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	sub := Subscription{Type: "*"}.WithFilter(filter)
+	if sub.Topic != "activity" {
+		t.Fatalf("unexpected topic: %s", sub.Topic)
+	}
+	if sub.Filters != filter.JSON {
+		t.Fatalf("unexpected filters: %s", sub.Filters)
+	}
+	if sub.Type != "*" {
+		t.Fatalf("expected WithFilter to preserve other Subscription fields, got type %s", sub.Type)
+	}
+}
@@ -67,6 +67,11 @@ const (
 	ConnectionStatusConnecting   ConnectionStatus = "CONNECTING"
 	ConnectionStatusConnected    ConnectionStatus = "CONNECTED"
 	ConnectionStatusDisconnected ConnectionStatus = "DISCONNECTED"
+
+	// ConnectionStatusFailed is a terminal state reached once the client has
+	// exhausted its reconnect budget (RealTimeDataClientArgs.MaxReconnectAttempts).
+	// The client will not attempt to reconnect again after entering this state.
+	ConnectionStatusFailed ConnectionStatus = "FAILED"
 )
 
 // --- CLOB Market Payload Schemas ---
@@ -0,0 +1,306 @@
+// Package orderbook maintains live, decimal-precision L2 order books from the
+// realtime package's clob_market stream. It complements realtime.BookManager,
+// which keeps string-typed levels in a map; Book instead keeps each side as a
+// price-sorted slice of decimal.Decimal levels so callers can do arithmetic
+// (MidPrice, Spread) without parsing strings themselves. The two share the
+// same continuity-check design and IsBidSide helper -- see Manager's doc
+// comment for how gap detection works here.
+package orderbook
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/realtime"
+	"github.com/shopspring/decimal"
+)
+
+// Level is a single price level in an order book side.
+type Level struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+// HashFunc computes a continuity digest over a book's current state, so
+// Manager can tell whether applying a delta reproduced the state the server
+// says it should have produced. The default, DefaultHash, is this package's
+// best-effort guess at a digest scheme and is NOT confirmed to match however
+// Polymarket's servers compute the Hash they attach to price_change entries;
+// pass a ManagerArgs.HashFunc that replicates the real algorithm once it's
+// known, otherwise every legitimate delta may be misdetected as drift.
+type HashFunc func(market string, bids, asks []Level) string
+
+// Book is a live, thread-safe L2 order book for a single asset. It is
+// populated and mutated by a Manager; callers only read from it.
+type Book struct {
+	mu sync.RWMutex
+
+	assetID      string
+	market       string
+	tickSize     decimal.Decimal
+	minOrderSize string
+	negRisk      bool
+	hash         string
+	hashFunc     HashFunc
+
+	bids          []Level // sorted ascending by price
+	asks          []Level // sorted ascending by price
+	lastTimestamp int64   // last-seen price_change timestamp, for gap detection
+}
+
+// AssetID returns the CLOB token/asset ID the book tracks.
+func (b *Book) AssetID() string {
+	return b.assetID
+}
+
+// Market returns the condition/market ID the book belongs to.
+func (b *Book) Market() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.market
+}
+
+// MinOrderSize returns the minimum order size last reported for the market.
+func (b *Book) MinOrderSize() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.minOrderSize
+}
+
+// NegRisk reports whether the market is a neg-risk market.
+func (b *Book) NegRisk() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.negRisk
+}
+
+// Bids returns a snapshot of the bid side, sorted descending by price (best bid first).
+func (b *Book) Bids() []Level {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	out := make([]Level, len(b.bids))
+	for i, lvl := range b.bids {
+		out[len(b.bids)-1-i] = lvl
+	}
+	return out
+}
+
+// Asks returns a snapshot of the ask side, sorted ascending by price (best ask first).
+func (b *Book) Asks() []Level {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	out := make([]Level, len(b.asks))
+	copy(out, b.asks)
+	return out
+}
+
+// BestBid returns the highest-priced bid level. ok is false if the book has no bids.
+func (b *Book) BestBid() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return Level{}, false
+	}
+	return b.bids[len(b.bids)-1], true
+}
+
+// BestAsk returns the lowest-priced ask level. ok is false if the book has no asks.
+func (b *Book) BestAsk() (Level, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return Level{}, false
+	}
+	return b.asks[0], true
+}
+
+// MidPrice returns the midpoint between the best bid and best ask. ok is false
+// unless both sides currently have at least one level.
+func (b *Book) MidPrice() (decimal.Decimal, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return decimal.Zero, false
+	}
+	ask, ok := b.BestAsk()
+	if !ok {
+		return decimal.Zero, false
+	}
+	return bid.Price.Add(ask.Price).Div(decimal.NewFromInt(2)), true
+}
+
+// Spread returns the difference between the best ask and the best bid. ok is
+// false unless both sides currently have at least one level.
+func (b *Book) Spread() (decimal.Decimal, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return decimal.Zero, false
+	}
+	ask, ok := b.BestAsk()
+	if !ok {
+		return decimal.Zero, false
+	}
+	return ask.Price.Sub(bid.Price), true
+}
+
+// contentHash digests the book's current bids/asks via hashFunc, so Manager
+// can tell whether applying a delta actually brought the local book back in
+// sync with whatever state hashFunc says the server expects, rather than
+// just recording what the server last told us. See HashFunc's doc comment
+// for the caveat that the default digest scheme is unverified.
+func (b *Book) contentHash() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.hashLocked()
+}
+
+// hashLocked computes contentHash's digest; callers must hold b.mu.
+func (b *Book) hashLocked() string {
+	hf := b.hashFunc
+	if hf == nil {
+		hf = DefaultHash
+	}
+	return hf(b.market, b.bids, b.asks)
+}
+
+// DefaultHash is the HashFunc used when ManagerArgs.HashFunc isn't set. It
+// sha1-digests the market ID followed by each bid then ask level as
+// "price:size", in the books' own sorted order. This is this package's
+// best-effort guess at a continuity digest, not a confirmed reproduction of
+// Polymarket's server-side hash algorithm -- see HashFunc.
+func DefaultHash(market string, bids, asks []Level) string {
+	var sb strings.Builder
+	sb.WriteString(market)
+	for _, lvl := range bids {
+		sb.WriteString("|b:")
+		sb.WriteString(lvl.Price.String())
+		sb.WriteByte(':')
+		sb.WriteString(lvl.Size.String())
+	}
+	for _, lvl := range asks {
+		sb.WriteString("|a:")
+		sb.WriteString(lvl.Price.String())
+		sb.WriteByte(':')
+		sb.WriteString(lvl.Size.String())
+	}
+	sum := sha1.Sum([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// seed replaces both sides wholesale from an agg_orderbook snapshot.
+func (b *Book) seed(market string, tickSize decimal.Decimal, minOrderSize string, negRisk bool, hash string, bids, asks []Level) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.market = market
+	b.tickSize = tickSize
+	b.minOrderSize = minOrderSize
+	b.negRisk = negRisk
+	b.hash = hash
+	b.lastTimestamp = 0 // a fresh snapshot may restart the server's timestamp sequence
+	b.bids = sortLevels(bids)
+	b.asks = sortLevels(asks)
+}
+
+// recordTimestamp reports whether ts looks out of order relative to the last
+// price_change timestamp seen for this book, and records ts as the new
+// high-water mark either way.
+//
+// This only catches reordering: a price_change that arrives with an earlier
+// timestamp than one already applied. A price_change that is dropped in
+// transit but otherwise arrives in order passes this check undetected --
+// that's what applyDelta's returned content hash is for.
+func (b *Book) recordTimestamp(ts int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	gap := realtime.DetectTimestampGap(ts, b.lastTimestamp)
+	if ts > b.lastTimestamp {
+		b.lastTimestamp = ts
+	}
+	return gap
+}
+
+// applyDelta upserts a single price level (zero size deletes it), records the
+// Hash the server attached to this change, and returns the book's own content
+// hash immediately afterward so the caller can check the two agree.
+func (b *Book) applyDelta(isBid bool, price, size decimal.Decimal, hash string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if isBid {
+		b.bids = upsertLevel(b.bids, price, size)
+	} else {
+		b.asks = upsertLevel(b.asks, price, size)
+	}
+	b.hash = hash
+	return b.hashLocked()
+}
+
+// requantize re-buckets every level to tickSize, summing the sizes of levels
+// that collide after rounding to the new tick.
+func (b *Book) requantize(tickSize decimal.Decimal) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tickSize = tickSize
+	b.bids = requantizeLevels(b.bids, tickSize)
+	b.asks = requantizeLevels(b.asks, tickSize)
+}
+
+// upsertLevel inserts, updates, or removes (on zero size) the level at price
+// within levels, which must be sorted ascending by price. It uses binary
+// search to locate the insertion point rather than scanning linearly.
+func upsertLevel(levels []Level, price, size decimal.Decimal) []Level {
+	idx := sort.Search(len(levels), func(i int) bool { return !levels[i].Price.LessThan(price) })
+
+	if idx < len(levels) && levels[idx].Price.Equal(price) {
+		if size.IsZero() {
+			return append(levels[:idx], levels[idx+1:]...)
+		}
+		levels[idx].Size = size
+		return levels
+	}
+
+	if size.IsZero() {
+		return levels
+	}
+
+	levels = append(levels, Level{})
+	copy(levels[idx+1:], levels[idx:])
+	levels[idx] = Level{Price: price, Size: size}
+	return levels
+}
+
+func sortLevels(levels []Level) []Level {
+	out := make([]Level, len(levels))
+	copy(out, levels)
+	sort.Slice(out, func(i, j int) bool { return out[i].Price.LessThan(out[j].Price) })
+	return out
+}
+
+func requantizeLevels(levels []Level, tickSize decimal.Decimal) []Level {
+	if tickSize.IsZero() {
+		return sortLevels(levels)
+	}
+
+	var out []Level
+	for _, lvl := range levels {
+		rounded := lvl.Price.DivRound(tickSize, 0).Mul(tickSize)
+
+		idx := sort.Search(len(out), func(i int) bool { return !out[i].Price.LessThan(rounded) })
+		if idx < len(out) && out[idx].Price.Equal(rounded) {
+			out[idx].Size = out[idx].Size.Add(lvl.Size)
+			continue
+		}
+
+		out = append(out, Level{})
+		copy(out[idx+1:], out[idx:])
+		out[idx] = Level{Price: rounded, Size: lvl.Size}
+	}
+	return out
+}
@@ -0,0 +1,393 @@
+package orderbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/realtime"
+	"github.com/shopspring/decimal"
+)
+
+// DefaultSubscriberBufferSize is the default buffer size of each channel
+// returned by Manager.Subscribe.
+const DefaultSubscriberBufferSize = 16
+
+// BookUpdate is sent on a Manager subscriber channel whenever the
+// corresponding asset's book changes.
+type BookUpdate struct {
+	AssetID string
+	Seq     uint64
+}
+
+// ManagerArgs configures a Manager.
+type ManagerArgs struct {
+	// AssetIDs is the set of CLOB token/asset IDs to build and maintain books for.
+	AssetIDs []string
+
+	// SubscriberBufferSize sets the buffer size of channels returned by
+	// Subscribe. Defaults to DefaultSubscriberBufferSize. A full channel drops
+	// the notification rather than blocking message processing.
+	SubscriberBufferSize int
+
+	// OnMessage, if set, is invoked with every message after Manager has
+	// applied it, so a caller can both maintain books and handle the raw stream.
+	OnMessage func(client *realtime.RealTimeDataClient, message realtime.Message)
+
+	// HashFunc overrides the continuity digest each Book uses to check
+	// whether applying a price_change reproduced the state the server
+	// expects. Defaults to orderbook.DefaultHash, which is an unverified
+	// guess at the real algorithm -- see HashFunc's doc comment. Set this to
+	// the confirmed server-side algorithm once it's known, or resnapshot
+	// storms are likely on every legitimate delta.
+	HashFunc HashFunc
+}
+
+// Manager subscribes to the clob_market topic for a fixed set of asset IDs
+// and maintains a live Book for each one by seeding from agg_orderbook
+// snapshots and applying price_change deltas, tick_size_change
+// requantizations, and market_resolved teardowns.
+//
+// Continuity across deltas is checked two ways: Book.recordTimestamp catches
+// a price_change arriving out of order, and applying a delta recomputes a
+// digest of the book's own bids/asks (using ManagerArgs.HashFunc, or
+// DefaultHash if unset) and compares it against the Hash the server attached
+// to that price_change, catching a price_change dropped in transit (which,
+// being otherwise in order, the timestamp check alone would miss). Either
+// mismatch is treated as meaning the local book has drifted from the
+// server's state, and Manager requests a fresh snapshot to resynchronize.
+//
+// This assumes HashFunc's digest scheme actually matches however the server
+// computed Hash. DefaultHash is this package's best-effort guess, not a
+// confirmed reproduction of Polymarket's algorithm; if it doesn't match,
+// every delta mismatches and every delta triggers a resnapshot. Set
+// ManagerArgs.HashFunc to the real algorithm once it's known.
+//
+// realtime.BookManager maintains the same books from the same stream using
+// string/map-typed levels instead of this package's decimal.Decimal ones; see
+// BookManager's doc comment for when to pick one over the other. The two
+// share this gap-detection design and the IsBidSide helper rather than
+// letting that logic drift apart.
+//
+// Assign Manager.OnMessage as a RealTimeDataClientArgs.OnMessage callback, and
+// call SubscribeFeed once the client has connected.
+type Manager struct {
+	args ManagerArgs
+
+	mu     sync.RWMutex
+	tracks map[string]*track
+}
+
+// track holds a Book plus the bookkeeping (sequence number, seed state,
+// fan-out subscribers) a Manager needs but that doesn't belong on Book itself.
+type track struct {
+	mu          sync.Mutex
+	book        *Book
+	seq         uint64
+	seeded      bool
+	subscribers []chan BookUpdate
+}
+
+// NewManager creates a Manager tracking the given asset IDs.
+func NewManager(args ManagerArgs) *Manager {
+	tracks := make(map[string]*track, len(args.AssetIDs))
+	for _, assetID := range args.AssetIDs {
+		tracks[assetID] = &track{book: &Book{assetID: assetID, hashFunc: args.HashFunc}}
+	}
+	return &Manager{args: args, tracks: tracks}
+}
+
+// SubscribeFeed sends the clob_market subscription covering every tracked
+// asset ID. Call it from a RealTimeDataClientArgs.OnConnect callback so books
+// are (re)seeded on every connect, including reconnects.
+func (m *Manager) SubscribeFeed(ctx context.Context, client *realtime.RealTimeDataClient) error {
+	filters, err := json.Marshal(m.assetIDs())
+	if err != nil {
+		return fmt.Errorf("failed to build clob_market filters: %w", err)
+	}
+
+	return client.Subscribe(ctx, realtime.SubscriptionMessage{
+		Subscriptions: []realtime.Subscription{
+			{Topic: "clob_market", Type: "*", Filters: string(filters)},
+		},
+	})
+}
+
+func (m *Manager) assetIDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.tracks))
+	for id := range m.tracks {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Book returns the tracked Book for assetID. ok is false if assetID isn't tracked.
+func (m *Manager) Book(assetID string) (*Book, bool) {
+	t := m.trackFor(assetID)
+	if t == nil {
+		return nil, false
+	}
+	return t.book, true
+}
+
+// Subscribe returns a channel that receives a BookUpdate every time assetID's
+// book changes. The channel is closed once the market resolves or Unsubscribe
+// is called for it. It returns nil if assetID isn't tracked.
+func (m *Manager) Subscribe(assetID string) <-chan BookUpdate {
+	t := m.trackFor(assetID)
+	if t == nil {
+		return nil
+	}
+
+	bufSize := m.args.SubscriberBufferSize
+	if bufSize <= 0 {
+		bufSize = DefaultSubscriberBufferSize
+	}
+
+	ch := make(chan BookUpdate, bufSize)
+	t.mu.Lock()
+	t.subscribers = append(t.subscribers, ch)
+	t.mu.Unlock()
+
+	return ch
+}
+
+// Unsubscribe removes and closes a channel previously returned by Subscribe.
+// It is a no-op if ch was already removed (e.g. by a market_resolved teardown).
+func (m *Manager) Unsubscribe(assetID string, ch <-chan BookUpdate) {
+	t := m.trackFor(assetID)
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, sub := range t.subscribers {
+		if sub == ch {
+			t.subscribers = append(t.subscribers[:i], t.subscribers[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
+// OnMessage applies a clob_market message to the relevant book(s). Assign it
+// as a RealTimeDataClientArgs.OnMessage callback.
+func (m *Manager) OnMessage(client *realtime.RealTimeDataClient, message realtime.Message) {
+	if message.Topic == "clob_market" {
+		payloadBytes, err := json.Marshal(message.Payload)
+		if err != nil {
+			log.Printf("orderbook: failed to re-marshal payload: %v", err)
+		} else {
+			switch message.Type {
+			case "agg_orderbook":
+				m.applyOrderBookEntries(payloadBytes)
+			case "price_change":
+				m.applyPriceChange(client, payloadBytes)
+			case "tick_size_change":
+				m.applyTickSizeChange(payloadBytes)
+			case "market_resolved":
+				m.applyMarketResolved(payloadBytes)
+			}
+		}
+	}
+
+	if m.args.OnMessage != nil {
+		m.args.OnMessage(client, message)
+	}
+}
+
+func (m *Manager) applyOrderBookEntries(payloadBytes []byte) {
+	var entries []realtime.OrderBookEntry
+	if err := json.Unmarshal(payloadBytes, &entries); err != nil || len(entries) == 0 {
+		var entry realtime.OrderBookEntry
+		if err := json.Unmarshal(payloadBytes, &entry); err != nil {
+			return
+		}
+		entries = []realtime.OrderBookEntry{entry}
+	}
+
+	for _, entry := range entries {
+		t := m.trackFor(entry.AssetID)
+		if t == nil {
+			continue
+		}
+
+		tickSize, _ := decimal.NewFromString(entry.TickSize)
+		t.book.seed(entry.Market, tickSize, entry.MinOrderSize, entry.NegRisk, entry.Hash, decodeLevels(entry.Bids), decodeLevels(entry.Asks))
+
+		t.mu.Lock()
+		t.seeded = true
+		t.seq++
+		seq := t.seq
+		t.mu.Unlock()
+
+		m.publish(t, entry.AssetID, seq)
+	}
+}
+
+func (m *Manager) applyPriceChange(client *realtime.RealTimeDataClient, payloadBytes []byte) {
+	var payload realtime.PriceChangePayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	ts, _ := strconv.ParseInt(payload.Timestamp, 10, 64)
+
+	for _, pc := range payload.PriceChanges {
+		t := m.trackFor(pc.AssetID)
+		if t == nil {
+			continue
+		}
+
+		t.mu.Lock()
+		seeded := t.seeded
+		t.mu.Unlock()
+		if !seeded {
+			continue
+		}
+
+		if t.book.recordTimestamp(ts) {
+			log.Printf("orderbook: detected out-of-order price_change for asset %s, resnapshotting", pc.AssetID)
+			m.resnapshot(client, pc.AssetID)
+			continue
+		}
+
+		price, err1 := decimal.NewFromString(pc.Price)
+		size, err2 := decimal.NewFromString(pc.Size)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		newHash := t.book.applyDelta(realtime.IsBidSide(pc.Side), price, size, pc.Hash)
+
+		t.mu.Lock()
+		t.seq++
+		seq := t.seq
+		t.mu.Unlock()
+
+		m.publish(t, pc.AssetID, seq)
+
+		if pc.Hash != "" && newHash != pc.Hash {
+			log.Printf("orderbook: local book hash mismatch for asset %s after applying delta (likely a dropped price_change), resnapshotting", pc.AssetID)
+			m.resnapshot(client, pc.AssetID)
+		}
+	}
+}
+
+func (m *Manager) applyTickSizeChange(payloadBytes []byte) {
+	var payload realtime.TickSizeChangePayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	newTick, err := decimal.NewFromString(payload.NewTickSize)
+	if err != nil {
+		return
+	}
+
+	for _, assetID := range payload.AssetID {
+		t := m.trackFor(assetID)
+		if t == nil {
+			continue
+		}
+		t.book.requantize(newTick)
+
+		t.mu.Lock()
+		t.seq++
+		seq := t.seq
+		t.mu.Unlock()
+
+		m.publish(t, assetID, seq)
+	}
+}
+
+func (m *Manager) applyMarketResolved(payloadBytes []byte) {
+	var payload realtime.ClobMarketPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, assetID := range payload.AssetIDs {
+		t, ok := m.tracks[assetID]
+		if !ok {
+			continue
+		}
+
+		t.mu.Lock()
+		for _, ch := range t.subscribers {
+			close(ch)
+		}
+		t.subscribers = nil
+		t.mu.Unlock()
+
+		delete(m.tracks, assetID)
+	}
+}
+
+// resnapshot forces the server to resend a fresh agg_orderbook for assetID by
+// briefly unsubscribing and resubscribing. It runs asynchronously so a single
+// reset doesn't stall processing of other assets' messages.
+func (m *Manager) resnapshot(client *realtime.RealTimeDataClient, assetID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		filters, err := json.Marshal([]string{assetID})
+		if err != nil {
+			log.Printf("orderbook: failed to build resnapshot filter for %s: %v", assetID, err)
+			return
+		}
+		sub := realtime.SubscriptionMessage{
+			Subscriptions: []realtime.Subscription{{Topic: "clob_market", Type: "*", Filters: string(filters)}},
+		}
+
+		if err := client.Unsubscribe(ctx, sub); err != nil {
+			log.Printf("orderbook: resnapshot unsubscribe failed for %s: %v", assetID, err)
+		}
+		if err := client.Subscribe(ctx, sub); err != nil {
+			log.Printf("orderbook: resnapshot subscribe failed for %s: %v", assetID, err)
+		}
+	}()
+}
+
+func (m *Manager) trackFor(assetID string) *track {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tracks[assetID]
+}
+
+func (m *Manager) publish(t *track, assetID string, seq uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, ch := range t.subscribers {
+		select {
+		case ch <- BookUpdate{AssetID: assetID, Seq: seq}:
+		default:
+			// Drop the notification rather than block message processing; Book
+			// always reflects the latest state regardless.
+		}
+	}
+}
+
+func decodeLevels(levels []realtime.OrderBookLevel) []Level {
+	out := make([]Level, 0, len(levels))
+	for _, lvl := range levels {
+		price, err1 := decimal.NewFromString(lvl.Price)
+		size, err2 := decimal.NewFromString(lvl.Size)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		out = append(out, Level{Price: price, Size: size})
+	}
+	return out
+}
@@ -0,0 +1,259 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HuakunShen/polymarket-kit/go-client/realtime"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+// newFeedServer starts an httptest WS server that writes the given frames in
+// order, a small delay apart, and records every "action" field of every
+// message it receives back from the client onto actions.
+func newFeedServer(t *testing.T, frames []string, actions chan<- string) (*httptest.Server, string) {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		go func() {
+			for {
+				_, data, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				var msg struct {
+					Action string `json:"action"`
+				}
+				if json.Unmarshal(data, &msg) == nil && msg.Action != "" {
+					select {
+					case actions <- msg.Action:
+					default:
+					}
+				}
+			}
+		}()
+
+		for _, frame := range frames {
+			time.Sleep(20 * time.Millisecond)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+				return
+			}
+		}
+
+		<-r.Context().Done()
+	}))
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
+	return server, wsURL
+}
+
+// TestManagerResnapshotsOnHashMismatch verifies that a price_change whose Hash
+// doesn't match the book's content after the delta is applied -- as happens
+// when an earlier price_change was dropped -- triggers a resnapshot, rather
+// than only resnapshotting on a duplicate/unchanged hash.
+func TestManagerResnapshotsOnHashMismatch(t *testing.T) {
+	snapshot := `{"topic":"clob_market","type":"agg_orderbook","payload":{"market":"0xabc","asset_id":"asset-1","hash":"seed-hash","bids":[{"price":"0.50","size":"10"}],"asks":[{"price":"0.55","size":"10"}],"tick_size":"0.01"}}`
+	// This price_change's Hash doesn't describe the book that results from
+	// applying it (simulating a dropped predecessor), so it should never match.
+	priceChange := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"stale-or-wrong-hash","p":"0.60","s":"BUY","si":"5"}],"t":"1"}}`
+
+	actions := make(chan string, 8)
+	server, wsURL := newFeedServer(t, []string{snapshot, priceChange}, actions)
+	defer server.Close()
+
+	manager := NewManager(ManagerArgs{AssetIDs: []string{"asset-1"}})
+
+	client := realtime.NewRealTimeDataClient(realtime.RealTimeDataClientArgs{
+		Host:      wsURL,
+		OnMessage: manager.OnMessage,
+	})
+	client.Connect()
+	defer client.Close()
+
+	var sawUnsubscribe, sawResubscribe bool
+	timeout := time.After(5 * time.Second)
+	for !sawResubscribe {
+		select {
+		case action := <-actions:
+			switch action {
+			case "unsubscribe":
+				sawUnsubscribe = true
+			case "subscribe":
+				if sawUnsubscribe {
+					sawResubscribe = true
+				}
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for resnapshot (unsubscribe+subscribe) after hash mismatch")
+		}
+	}
+}
+
+// TestManagerResnapshotsOnOutOfOrderTimestamp verifies that a price_change
+// whose timestamp is older than one already applied for the same market --
+// as happens when the feed reorders messages in transit -- triggers a
+// resnapshot, even though its Hash would otherwise match.
+func TestManagerResnapshotsOnOutOfOrderTimestamp(t *testing.T) {
+	// ahead's Hash is computed over the state that results from applying it,
+	// so it alone would not trigger the hash-mismatch check; any resnapshot
+	// observed must come from stale's older timestamp instead.
+	aheadHash := DefaultHash("0xabc",
+		[]Level{
+			{Price: decimal.RequireFromString("0.50"), Size: decimal.RequireFromString("10")},
+			{Price: decimal.RequireFromString("0.60"), Size: decimal.RequireFromString("5")},
+		},
+		[]Level{{Price: decimal.RequireFromString("0.55"), Size: decimal.RequireFromString("10")}},
+	)
+
+	snapshot := `{"topic":"clob_market","type":"agg_orderbook","payload":{"market":"0xabc","asset_id":"asset-1","hash":"seed-hash","bids":[{"price":"0.50","size":"10"}],"asks":[{"price":"0.55","size":"10"}],"tick_size":"0.01"}}`
+	ahead := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"` + aheadHash + `","p":"0.60","s":"BUY","si":"5"}],"t":"100"}}`
+	stale := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"irrelevant","p":"0.61","s":"BUY","si":"5"}],"t":"50"}}`
+
+	actions := make(chan string, 8)
+	server, wsURL := newFeedServer(t, []string{snapshot, ahead, stale}, actions)
+	defer server.Close()
+
+	manager := NewManager(ManagerArgs{AssetIDs: []string{"asset-1"}})
+
+	client := realtime.NewRealTimeDataClient(realtime.RealTimeDataClientArgs{
+		Host:      wsURL,
+		OnMessage: manager.OnMessage,
+	})
+	client.Connect()
+	defer client.Close()
+
+	var sawUnsubscribe, sawResubscribe bool
+	timeout := time.After(5 * time.Second)
+	for !sawResubscribe {
+		select {
+		case action := <-actions:
+			switch action {
+			case "unsubscribe":
+				sawUnsubscribe = true
+			case "subscribe":
+				if sawUnsubscribe {
+					sawResubscribe = true
+				}
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for resnapshot (unsubscribe+subscribe) after an out-of-order price_change")
+		}
+	}
+}
+
+// TestManagerResnapshotResetsTimestampGapDetection verifies that a resnapshot
+// (a fresh agg_orderbook) resets the gap-detection high-water mark, so a
+// feed that restarts its timestamp sequence after a reconnect isn't
+// permanently misdetected as sending out-of-order price_changes.
+func TestManagerResnapshotResetsTimestampGapDetection(t *testing.T) {
+	highTimestampHash := DefaultHash("0xabc",
+		[]Level{
+			{Price: decimal.RequireFromString("0.50"), Size: decimal.RequireFromString("10")},
+			{Price: decimal.RequireFromString("0.60"), Size: decimal.RequireFromString("5")},
+		},
+		[]Level{{Price: decimal.RequireFromString("0.55"), Size: decimal.RequireFromString("10")}},
+	)
+
+	firstSnapshot := `{"topic":"clob_market","type":"agg_orderbook","payload":{"market":"0xabc","asset_id":"asset-1","hash":"seed-hash","bids":[{"price":"0.50","size":"10"}],"asks":[{"price":"0.55","size":"10"}],"tick_size":"0.01"}}`
+	highTimestampDelta := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"` + highTimestampHash + `","p":"0.60","s":"BUY","si":"5"}],"t":"1000"}}`
+	// A second agg_orderbook, as if the feed reconnected and resnapshotted;
+	// its own timestamp sequence restarts from a small value.
+	secondSnapshot := `{"topic":"clob_market","type":"agg_orderbook","payload":{"market":"0xabc","asset_id":"asset-1","hash":"seed-hash-2","bids":[{"price":"0.50","size":"10"}],"asks":[{"price":"0.55","size":"10"}],"tick_size":"0.01"}}`
+	lowTimestampDelta := DefaultHash("0xabc",
+		[]Level{
+			{Price: decimal.RequireFromString("0.50"), Size: decimal.RequireFromString("10")},
+			{Price: decimal.RequireFromString("0.61"), Size: decimal.RequireFromString("5")},
+		},
+		[]Level{{Price: decimal.RequireFromString("0.55"), Size: decimal.RequireFromString("10")}},
+	)
+	afterReconnect := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"` + lowTimestampDelta + `","p":"0.61","s":"BUY","si":"5"}],"t":"10"}}`
+
+	actions := make(chan string, 8)
+	server, wsURL := newFeedServer(t, []string{firstSnapshot, highTimestampDelta, secondSnapshot, afterReconnect}, actions)
+	defer server.Close()
+
+	manager := NewManager(ManagerArgs{AssetIDs: []string{"asset-1"}})
+	updates := manager.Subscribe("asset-1")
+
+	client := realtime.NewRealTimeDataClient(realtime.RealTimeDataClientArgs{
+		Host:      wsURL,
+		OnMessage: manager.OnMessage,
+	})
+	client.Connect()
+	defer client.Close()
+
+	// Wait for all four messages to be applied (four BookUpdates), then give
+	// an (incorrect) resnapshot from the post-reconnect delta a window to show up.
+	for i := 0; i < 4; i++ {
+		select {
+		case <-updates:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for book updates")
+		}
+	}
+
+	select {
+	case action := <-actions:
+		t.Fatalf("unexpected %q after reconnect; the snapshot should have reset gap detection", action)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+// TestManagerNoResnapshotOnHashMatch verifies the happy path: a price_change
+// whose Hash was computed (via DefaultHash) over the book state that results
+// from applying it is treated as continuous, so Manager does not
+// unsubscribe/resubscribe. This pins down that the mismatch check in
+// TestManagerResnapshotsOnHashMismatch only fires on an actual digest
+// disagreement, not on every delta regardless of Hash.
+func TestManagerNoResnapshotOnHashMatch(t *testing.T) {
+	resultingHash := DefaultHash("0xabc",
+		[]Level{{Price: decimal.RequireFromString("0.50"), Size: decimal.RequireFromString("10")}},
+		[]Level{{Price: decimal.RequireFromString("0.55"), Size: decimal.RequireFromString("5")}},
+	)
+
+	snapshot := `{"topic":"clob_market","type":"agg_orderbook","payload":{"market":"0xabc","asset_id":"asset-1","hash":"seed-hash","bids":[{"price":"0.50","size":"10"}],"asks":[{"price":"0.55","size":"10"}],"tick_size":"0.01"}}`
+	priceChange := `{"topic":"clob_market","type":"price_change","payload":{"m":"0xabc","pc":[{"a":"asset-1","h":"` + resultingHash + `","p":"0.55","s":"SELL","si":"5"}],"t":"1"}}`
+
+	actions := make(chan string, 8)
+	server, wsURL := newFeedServer(t, []string{snapshot, priceChange}, actions)
+	defer server.Close()
+
+	manager := NewManager(ManagerArgs{AssetIDs: []string{"asset-1"}})
+
+	updates := manager.Subscribe("asset-1")
+
+	client := realtime.NewRealTimeDataClient(realtime.RealTimeDataClientArgs{
+		Host:      wsURL,
+		OnMessage: manager.OnMessage,
+	})
+	client.Connect()
+	defer client.Close()
+
+	// Wait for both the snapshot and the delta to be applied (two BookUpdates),
+	// then give any (incorrect) resnapshot a window to show up.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-updates:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for book updates")
+		}
+	}
+
+	select {
+	case action := <-actions:
+		t.Fatalf("unexpected %q after a hash-matching delta; continuity check should not have resnapshotted", action)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
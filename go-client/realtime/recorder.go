@@ -0,0 +1,96 @@
+package realtime
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// RecordedFrame is a single captured message, newline-delimited-JSON encoded by
+// Recorder and decoded by Replayer.
+type RecordedFrame struct {
+	// ReceivedAt is the local time the frame was handed to the Recorder, used by
+	// Replayer to pace playback by original inter-arrival time.
+	ReceivedAt time.Time `json:"received_at"`
+
+	// Message is the decoded frame as it was delivered to OnMessage.
+	Message Message `json:"message"`
+}
+
+// Recorder wraps a RealTimeDataClientArgs.OnMessage callback and streams every
+// message it sees, plus its receive timestamp, to a pluggable sink as
+// newline-delimited JSON. Pass its OnMessage method as the client's OnMessage
+// callback (optionally chaining to a downstream handler) to capture a session
+// for later offline testing or backtesting via Replayer.
+type Recorder struct {
+	mu   sync.Mutex
+	enc  *json.Encoder
+	next func(client *RealTimeDataClient, message Message)
+}
+
+// NewRecorder creates a Recorder that writes newline-delimited JSON frames to sink.
+// next, if non-nil, is invoked with every message after it has been recorded, so a
+// caller can record and process a live stream at the same time.
+func NewRecorder(sink io.Writer, next func(client *RealTimeDataClient, message Message)) *Recorder {
+	return &Recorder{
+		enc:  json.NewEncoder(sink),
+		next: next,
+	}
+}
+
+// OnMessage records message and its receive timestamp, then forwards it to next
+// if one was provided. Assign this method to RealTimeDataClientArgs.OnMessage.
+func (r *Recorder) OnMessage(client *RealTimeDataClient, message Message) {
+	frame := RecordedFrame{ReceivedAt: time.Now(), Message: message}
+
+	r.mu.Lock()
+	err := r.enc.Encode(frame)
+	r.mu.Unlock()
+	if err != nil {
+		// Recording is best-effort; a broken sink shouldn't take down live processing.
+		if r.next != nil {
+			r.next(client, message)
+		}
+		return
+	}
+
+	if r.next != nil {
+		r.next(client, message)
+	}
+}
+
+// NewFileSink opens (creating or truncating) path and returns it as a sink for NewRecorder.
+func NewFileSink(path string) (io.WriteCloser, error) {
+	return os.Create(path)
+}
+
+// NewGzipFileSink opens (creating or truncating) path and wraps it in a gzip
+// writer, for compact long-running recordings. The returned WriteCloser's
+// Close flushes and closes both the gzip stream and the underlying file.
+func NewGzipFileSink(path string) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipFileSink{file: f, gz: gzip.NewWriter(f)}, nil
+}
+
+type gzipFileSink struct {
+	file *os.File
+	gz   *gzip.Writer
+}
+
+func (s *gzipFileSink) Write(p []byte) (int, error) {
+	return s.gz.Write(p)
+}
+
+func (s *gzipFileSink) Close() error {
+	if err := s.gz.Close(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
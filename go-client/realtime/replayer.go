@@ -0,0 +1,162 @@
+package realtime
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// ReplayerArgs mirrors the callback shape of RealTimeDataClientArgs so handlers
+// written against a live feed can be pointed at a recording with minimal changes.
+type ReplayerArgs struct {
+	// OnMessage is invoked for every frame read from the recording, in order.
+	OnMessage func(replayer *Replayer, message Message)
+
+	// OnStatusChange is invoked as the replayer moves through connection states.
+	OnStatusChange func(status ConnectionStatus)
+
+	// SpeedMultiplier paces playback by the original inter-arrival time divided
+	// by this value (2 plays back twice as fast, 0.5 half as fast). Zero or
+	// negative (the default) replays every frame as fast as possible.
+	SpeedMultiplier float64
+}
+
+// Replayer reads a recording produced by Recorder and drives an OnMessage
+// callback from it, either as fast as possible or paced by the original
+// inter-arrival times. It exposes the same Connect/Disconnect/Subscribe/
+// Unsubscribe surface as RealTimeDataClient (Subscribe/Unsubscribe are no-ops)
+// so strategy code can be pointed at a recording without changes.
+type Replayer struct {
+	source io.Reader
+	args   ReplayerArgs
+
+	stopChan  chan struct{}
+	closeOnce sync.Once
+}
+
+// NewReplayer creates a Replayer that reads newline-delimited RecordedFrame JSON from source.
+func NewReplayer(source io.Reader, args ReplayerArgs) *Replayer {
+	return &Replayer{
+		source:   source,
+		args:     args,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// OpenFileSource opens a recording previously written via NewFileSink.
+func OpenFileSource(path string) (io.ReadCloser, error) {
+	return os.Open(path)
+}
+
+// OpenGzipFileSource opens a recording previously written via NewGzipFileSink.
+// The returned ReadCloser's Close also closes the underlying file.
+func OpenGzipFileSource(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipFileSource{file: f, gz: gz}, nil
+}
+
+type gzipFileSource struct {
+	file *os.File
+	gz   *gzip.Reader
+}
+
+func (s *gzipFileSource) Read(p []byte) (int, error) { return s.gz.Read(p) }
+
+func (s *gzipFileSource) Close() error {
+	if err := s.gz.Close(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// Connect starts replaying the recording in the background.
+func (r *Replayer) Connect() *Replayer {
+	r.notifyStatusChange(ConnectionStatusConnecting)
+	go r.run()
+	return r
+}
+
+func (r *Replayer) run() {
+	r.notifyStatusChange(ConnectionStatusConnected)
+
+	dec := json.NewDecoder(r.source)
+	var prev time.Time
+	first := true
+
+	for {
+		select {
+		case <-r.stopChan:
+			r.notifyStatusChange(ConnectionStatusDisconnected)
+			return
+		default:
+		}
+
+		var frame RecordedFrame
+		if err := dec.Decode(&frame); err != nil {
+			if !errors.Is(err, io.EOF) {
+				log.Printf("replayer decode error: %v", err)
+			}
+			break
+		}
+
+		if !first && r.args.SpeedMultiplier > 0 {
+			if gap := frame.ReceivedAt.Sub(prev); gap > 0 {
+				wait := time.Duration(float64(gap) / r.args.SpeedMultiplier)
+				select {
+				case <-time.After(wait):
+				case <-r.stopChan:
+					r.notifyStatusChange(ConnectionStatusDisconnected)
+					return
+				}
+			}
+		}
+		prev = frame.ReceivedAt
+		first = false
+
+		if r.args.OnMessage != nil {
+			r.args.OnMessage(r, frame.Message)
+		}
+	}
+
+	r.notifyStatusChange(ConnectionStatusDisconnected)
+}
+
+// Disconnect stops replaying the recording. Safe to call more than once.
+func (r *Replayer) Disconnect() {
+	r.closeOnce.Do(func() {
+		close(r.stopChan)
+	})
+}
+
+// Subscribe is a no-op: a recording replays exactly what was captured,
+// regardless of what a caller subscribes to. It exists so strategy code
+// written against RealTimeDataClient compiles unchanged against a Replayer.
+func (r *Replayer) Subscribe(ctx context.Context, msg SubscriptionMessage) error {
+	return nil
+}
+
+// Unsubscribe is a no-op; see Subscribe.
+func (r *Replayer) Unsubscribe(ctx context.Context, msg SubscriptionMessage) error {
+	return nil
+}
+
+func (r *Replayer) notifyStatusChange(status ConnectionStatus) {
+	if r.args.OnStatusChange != nil {
+		r.args.OnStatusChange(status)
+	}
+}
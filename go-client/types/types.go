@@ -0,0 +1,47 @@
+// Package types holds the configuration and data types shared by the client
+// package's CLOB HTTP client, independent of any particular HTTP transport.
+package types
+
+import "time"
+
+// Chain identifies an EVM chain ID Polymarket operates on.
+type Chain int64
+
+const (
+	// ChainPolygon is Polygon mainnet, chain ID 137.
+	ChainPolygon Chain = 137
+
+	// ChainMumbai is the (deprecated) Mumbai testnet, chain ID 80001.
+	ChainMumbai Chain = 80001
+)
+
+// ApiKeyCreds are CLOB API key credentials, as returned by the CLOB's
+// create/derive API key endpoints.
+type ApiKeyCreds struct {
+	Key        string `json:"key"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// HTTPBackend selects which underlying HTTP implementation a ClobClient uses.
+type HTTPBackend string
+
+const (
+	// HTTPBackendNetHTTP uses Go's standard net/http client. It is the default.
+	HTTPBackendNetHTTP HTTPBackend = "net/http"
+
+	// HTTPBackendFastHTTP uses valyala/fasthttp, trading net/http's simplicity
+	// for lower per-request allocations under high request rates.
+	HTTPBackendFastHTTP HTTPBackend = "fasthttp"
+)
+
+// RequestLogEntry describes one logical HTTP request, passed to a
+// ClientConfig's OnRequest/OnResponse hooks. Err and Status are zero-valued
+// on the OnRequest call, since the request hasn't been sent yet.
+type RequestLogEntry struct {
+	Method  string
+	Path    string
+	Status  int
+	Latency time.Duration
+	Err     error
+}